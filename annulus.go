@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Annulus represents a ring between an inner and an outer circle sharing the same center
+type Annulus struct {
+	Center      Coord // Center coordinate of the ring
+	InnerRadius int32 // Radius of the hole, at most OuterRadius
+	OuterRadius int32 // Radius of the outer boundary
+}
+
+// NewAnnulus creates a new annulus with given center, inner and outer radius
+// Negative radii are clamped to 0 and the radii are swapped when the inner one is the larger.
+func NewAnnulus(center Coord, innerRadius, outerRadius int32) Annulus {
+	innerRadius, outerRadius = max(innerRadius, 0), max(outerRadius, 0)
+	return Annulus{
+		Center:      center,
+		InnerRadius: min(innerRadius, outerRadius),
+		OuterRadius: max(innerRadius, outerRadius),
+	}
+}
+
+// IsCoordInside checks if point is inside the ring, points on both boundaries are inside
+func (a *Annulus) IsCoordInside(p Coord) bool {
+	dst := CalDstCoordToCoordWithoutSqrt(a.Center, p)
+	inner, outer := float64(a.InnerRadius), float64(a.OuterRadius)
+	return inner*inner <= dst && dst <= outer*outer
+}
+
+// overlapsRange checks if a set whose distances to the center cover [near, far] meets the ring
+func (a *Annulus) overlapsRange(near, far float64) bool {
+	return near <= float64(a.OuterRadius) && far >= float64(a.InnerRadius)
+}
+
+// IsInterCircle checks if the ring intersects with circle
+// Circles fully inside the hole do not intersect
+func (a *Annulus) IsInterCircle(c *Circle) bool {
+	dst := CalDstCoordToCoord(a.Center, c.Center)
+	r := float64(c.Radius)
+	return a.overlapsRange(max(dst-r, 0), dst+r)
+}
+
+// IsInterSegment checks if the ring intersects with line segment
+func (a *Annulus) IsInterSegment(s *Segment) bool {
+	far := max(CalDstCoordToCoord(a.Center, s.A), CalDstCoordToCoord(a.Center, s.B))
+	return a.overlapsRange(s.CalCoordDst(a.Center), far)
+}
+
+// IsInterConvex checks if the ring intersects with a convex polygon
+func (a *Annulus) IsInterConvex(p ConvexPolygon) bool {
+	coords := p.GetCoords()
+	near := CalDstCoordToPolygon(a.Center, NewSimplePolygon(coords, 0))
+	far := 0.
+	for _, c := range coords {
+		far = max(far, CalDstCoordToCoord(a.Center, c))
+	}
+	return a.overlapsRange(near, far)
+}
+
+// Support returns the farthest point of the outer circle in the direction
+func (a *Annulus) Support(dir Vector) Coord {
+	outer := NewCirCle(a.Center, a.OuterRadius)
+	return outer.Support(dir)
+}
+
+// RandCoord generates a random coordinate uniformly distributed within the ring
+// r: source of randomness, the global source of math/rand is used when nil
+func (a *Annulus) RandCoord(r *rand.Rand) Coord {
+	inner, outer := float64(a.InnerRadius), float64(a.OuterRadius)
+	// The square root keeps the density uniform over the area
+	dst := math.Sqrt(inner*inner + randFloat(r)*(outer*outer-inner*inner))
+	angle := randFloat(r) * 2 * math.Pi
+	return Coord{
+		X: a.Center.X + int32(math.Round(dst*math.Cos(angle))),
+		Z: a.Center.Z + int32(math.Round(dst*math.Sin(angle))),
+	}
+}
+
+// ToRect returns the bounding rectangle of the outer circle
+// Returns the minimum and maximum X,Z coordinates of the bounding rectangle
+func (a *Annulus) ToRect() (minX, minZ, maxX, maxZ int32) {
+	minX = a.Center.X - a.OuterRadius
+	minZ = a.Center.Z - a.OuterRadius
+	maxX = a.Center.X + a.OuterRadius
+	maxZ = a.Center.Z + a.OuterRadius
+	return
+}
+
+// GetLocationToBorder returns the relative position between ring and border
+func (a *Annulus) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := a.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
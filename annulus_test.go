@@ -0,0 +1,57 @@
+package geo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewAnnulus(t *testing.T) {
+	tests := []struct {
+		name         string
+		inner, outer int32
+		wantInner    int32
+		wantOuter    int32
+	}{
+		{name: "ordered", inner: 50, outer: 100, wantInner: 50, wantOuter: 100},
+		{name: "swapped", inner: 100, outer: 50, wantInner: 50, wantOuter: 100},
+		{name: "negative", inner: -10, outer: 100, wantInner: 0, wantOuter: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnnulus(Coord{}, tt.inner, tt.outer)
+			if a.InnerRadius != tt.wantInner || a.OuterRadius != tt.wantOuter {
+				t.Errorf("NewAnnulus() radii = %d, %d, want %d, %d", a.InnerRadius, a.OuterRadius, tt.wantInner, tt.wantOuter)
+			}
+		})
+	}
+}
+
+func TestAnnulusIsCoordInside(t *testing.T) {
+	a := NewAnnulus(Coord{X: 10, Z: 10}, 100, 50)
+	tests := []struct {
+		name string
+		p    Coord
+		want bool
+	}{
+		{name: "in the ring", p: Coord{X: 85, Z: 10}, want: true},
+		{name: "on the inner circle", p: Coord{X: 10, Z: 60}, want: true},
+		{name: "on the outer circle", p: Coord{X: -90, Z: 10}, want: true},
+		{name: "in the hole", p: Coord{X: 20, Z: 20}, want: false},
+		{name: "outside", p: Coord{X: 111, Z: 10}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.IsCoordInside(tt.p); got != tt.want {
+				t.Errorf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for range 100 {
+		p := a.RandCoord(r)
+		if dst := CalDstCoordToCoord(a.Center, p); dst < 49.5 || dst > 100.5 {
+			t.Fatalf("RandCoord() = %v at %v from the center, want within the ring", p, dst)
+		}
+	}
+}
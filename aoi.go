@@ -0,0 +1,446 @@
+package geo
+
+import (
+	"github.com/busyster996/geo/util"
+)
+
+// AOIEventType represents the kind of an area of interest notification
+type AOIEventType int8
+
+const (
+	AOIEnter AOIEventType = iota + 1 // Target entered the view of the watcher
+	AOILeave                         // Target left the view of the watcher
+	AOIMove                          // Target moved inside the view of the watcher
+)
+
+// AOIEvent represents an area of interest notification sent to a watcher about a target
+type AOIEvent[K comparable] struct {
+	Type    AOIEventType // Event type
+	Watcher K            // Entity whose view changed
+	Target  K            // Entity entering, leaving or moving in the view
+	Coord   Coord        // Position of the target
+}
+
+// AOIChannel returns a callback sending events to the channel
+// The channel must be buffered or drained by another goroutine, sends block the AOI otherwise.
+func AOIChannel[K comparable](ch chan<- AOIEvent[K]) func(AOIEvent[K]) {
+	return func(event AOIEvent[K]) {
+		ch <- event
+	}
+}
+
+// aoiEntity represents an entity tracked by the AOI
+type aoiEntity[K comparable] struct {
+	key      K
+	coord    Coord
+	radius   int32               // View radius
+	view     map[K]*aoiEntity[K] // Entities seen by this entity
+	watchers map[K]*aoiEntity[K] // Entities seeing this entity
+}
+
+// sees checks if the target is inside the view radius of the entity
+func (e *aoiEntity[K]) sees(target *aoiEntity[K]) bool {
+	// Differences are taken in 64 bits, entities may sit at opposite coordinate limits
+	dx := float64(int64(e.coord.X) - int64(target.coord.X))
+	dz := float64(int64(e.coord.Z) - int64(target.coord.Z))
+	radius := float64(e.radius)
+	return dx*dx+dz*dz <= radius*radius
+}
+
+// aoiIndex represents the spatial structure of an AOI
+type aoiIndex[K comparable] interface {
+	add(e *aoiEntity[K])                                            // Add an entity at its position
+	move(e *aoiEntity[K], old Coord)                                // Update an entity after its position changed
+	remove(e *aoiEntity[K])                                         // Remove an entity
+	around(e *aoiEntity[K], radius int32, fn func(o *aoiEntity[K])) // Visit other entities possibly within radius
+}
+
+// AOI represents an area of interest manager
+// Entities see the entities within their view radius and are notified through the callback
+// when others enter, leave or move in their view. It is not safe for concurrent use.
+type AOI[K comparable] struct {
+	callback  func(AOIEvent[K])
+	index     aoiIndex[K]
+	entities  map[K]*aoiEntity[K]
+	maxRadius int32 // Largest view radius ever entered, bounds the watcher search
+}
+
+// NewGridAOI creates a nine-grid AOI with square cells of the given size
+// The cell size is usually the common view radius, so an entity only checks the nine cells around it.
+func NewGridAOI[K comparable](cellSize int32, callback func(AOIEvent[K])) *AOI[K] {
+	return newAOI(&aoiGrid[K]{
+		cellSize: max(cellSize, 1),
+		cells:    make(map[Coord]map[K]*aoiEntity[K]),
+	}, callback)
+}
+
+// NewCrossListAOI creates an AOI keeping entities in two linked lists sorted by X and by Z
+// It needs no cell size and suits sparse maps or entities with very different view radiuses.
+func NewCrossListAOI[K comparable](callback func(AOIEvent[K])) *AOI[K] {
+	return newAOI(&aoiCrossList[K]{
+		nodes: make(map[K]*crossNode[K]),
+	}, callback)
+}
+
+// newAOI creates an AOI on the index
+func newAOI[K comparable](index aoiIndex[K], callback func(AOIEvent[K])) *AOI[K] {
+	if callback == nil {
+		callback = func(AOIEvent[K]) {}
+	}
+	return &AOI[K]{
+		callback: callback,
+		index:    index,
+		entities: make(map[K]*aoiEntity[K]),
+	}
+}
+
+// Len returns the number of entities
+func (a *AOI[K]) Len() int {
+	return len(a.entities)
+}
+
+// Get returns the position of an entity
+func (a *AOI[K]) Get(key K) (Coord, bool) {
+	e, exist := a.entities[key]
+	if !exist {
+		return Coord{}, false
+	}
+	return e.coord, true
+}
+
+// View returns the entities seen by an entity
+func (a *AOI[K]) View(key K) []K {
+	e, exist := a.entities[key]
+	if !exist {
+		return nil
+	}
+	return aoiKeys(e.view)
+}
+
+// Watchers returns the entities seeing an entity
+func (a *AOI[K]) Watchers(key K) []K {
+	e, exist := a.entities[key]
+	if !exist {
+		return nil
+	}
+	return aoiKeys(e.watchers)
+}
+
+// Enter adds an entity with its view radius, returns false if it already exists
+// The entity receives AOIEnter for every entity in its view and notifies the entities seeing it.
+func (a *AOI[K]) Enter(key K, coord Coord, radius int32) bool {
+	if _, exist := a.entities[key]; exist {
+		return false
+	}
+	e := &aoiEntity[K]{
+		key:      key,
+		coord:    coord,
+		radius:   max(radius, 0),
+		view:     make(map[K]*aoiEntity[K]),
+		watchers: make(map[K]*aoiEntity[K]),
+	}
+	a.entities[key] = e
+	a.maxRadius = max(a.maxRadius, e.radius)
+	a.index.add(e)
+
+	a.index.around(e, e.radius, func(o *aoiEntity[K]) {
+		if e.sees(o) {
+			a.show(e, o)
+		}
+	})
+	a.index.around(e, a.maxRadius, func(w *aoiEntity[K]) {
+		if w.sees(e) {
+			a.show(w, e)
+		}
+	})
+	return true
+}
+
+// Move updates the position of an entity, returns false if it does not exist
+// The entity receives AOIEnter and AOILeave as its view changes, entities seeing it
+// receive AOIEnter, AOIMove or AOILeave.
+func (a *AOI[K]) Move(key K, coord Coord) bool {
+	e, exist := a.entities[key]
+	if !exist {
+		return false
+	}
+	if e.coord == coord {
+		return true
+	}
+	old := e.coord
+	e.coord = coord
+	a.index.move(e, old)
+
+	// Changes of the view of the entity
+	visible := make(map[K]bool, len(e.view))
+	a.index.around(e, e.radius, func(o *aoiEntity[K]) {
+		if !e.sees(o) {
+			return
+		}
+		visible[o.key] = true
+		if _, seen := e.view[o.key]; !seen {
+			a.show(e, o)
+		}
+	})
+	for k, o := range e.view {
+		if !visible[k] {
+			a.hide(e, o)
+		}
+	}
+
+	// Changes of the views seeing the entity
+	checked := make(map[K]bool, len(e.watchers))
+	a.index.around(e, a.maxRadius, func(w *aoiEntity[K]) {
+		checked[w.key] = true
+		a.notify(w, e)
+	})
+	for k, w := range e.watchers {
+		if !checked[k] {
+			a.notify(w, e)
+		}
+	}
+	return true
+}
+
+// Leave removes an entity, returns false if it does not exist
+// Entities seeing it receive AOILeave, and it receives AOILeave for every entity in its view.
+func (a *AOI[K]) Leave(key K) bool {
+	e, exist := a.entities[key]
+	if !exist {
+		return false
+	}
+	for _, w := range e.watchers {
+		a.hide(w, e)
+	}
+	for _, o := range e.view {
+		a.hide(e, o)
+	}
+	a.index.remove(e)
+	delete(a.entities, key)
+	return true
+}
+
+// notify sends the event matching the visibility change of the target for the watcher
+func (a *AOI[K]) notify(w, target *aoiEntity[K]) {
+	_, seen := w.view[target.key]
+	switch visible := w.sees(target); {
+	case visible && seen:
+		a.callback(AOIEvent[K]{Type: AOIMove, Watcher: w.key, Target: target.key, Coord: target.coord})
+	case visible:
+		a.show(w, target)
+	case seen:
+		a.hide(w, target)
+	}
+}
+
+// show adds the target to the view of the watcher
+func (a *AOI[K]) show(w, target *aoiEntity[K]) {
+	w.view[target.key] = target
+	target.watchers[w.key] = w
+	a.callback(AOIEvent[K]{Type: AOIEnter, Watcher: w.key, Target: target.key, Coord: target.coord})
+}
+
+// hide removes the target from the view of the watcher
+func (a *AOI[K]) hide(w, target *aoiEntity[K]) {
+	delete(w.view, target.key)
+	delete(target.watchers, w.key)
+	a.callback(AOIEvent[K]{Type: AOILeave, Watcher: w.key, Target: target.key, Coord: target.coord})
+}
+
+// aoiKeys returns the keys of an entity set
+func aoiKeys[K comparable](entities map[K]*aoiEntity[K]) []K {
+	keys := make([]K, 0, len(entities))
+	for k := range entities {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// aoiGrid represents the nine-grid index, cells are keyed by their column and row
+type aoiGrid[K comparable] struct {
+	cellSize int32
+	cells    map[Coord]map[K]*aoiEntity[K]
+}
+
+// cellOf returns the key of the cell containing the point
+func (g *aoiGrid[K]) cellOf(p Coord) Coord {
+	return Coord{X: floorDiv(p.X, g.cellSize), Z: floorDiv(p.Z, g.cellSize)}
+}
+
+func (g *aoiGrid[K]) add(e *aoiEntity[K]) {
+	cell := g.cellOf(e.coord)
+	entities, exist := g.cells[cell]
+	if !exist {
+		entities = make(map[K]*aoiEntity[K])
+		g.cells[cell] = entities
+	}
+	entities[e.key] = e
+}
+
+func (g *aoiGrid[K]) move(e *aoiEntity[K], old Coord) {
+	if g.cellOf(old) == g.cellOf(e.coord) {
+		return
+	}
+	g.removeAt(e, old)
+	g.add(e)
+}
+
+func (g *aoiGrid[K]) remove(e *aoiEntity[K]) {
+	g.removeAt(e, e.coord)
+}
+
+// removeAt removes the entity from the cell containing the point, empty cells are dropped
+func (g *aoiGrid[K]) removeAt(e *aoiEntity[K], p Coord) {
+	cell := g.cellOf(p)
+	delete(g.cells[cell], e.key)
+	if len(g.cells[cell]) == 0 {
+		delete(g.cells, cell)
+	}
+}
+
+func (g *aoiGrid[K]) around(e *aoiEntity[K], radius int32, fn func(o *aoiEntity[K])) {
+	// The range is clamped to the coordinate limits, cells are walked with 64-bit counters
+	minCell := g.cellOf(Coord{X: clampInt32(int64(e.coord.X) - int64(radius)), Z: clampInt32(int64(e.coord.Z) - int64(radius))})
+	maxCell := g.cellOf(Coord{X: clampInt32(int64(e.coord.X) + int64(radius)), Z: clampInt32(int64(e.coord.Z) + int64(radius))})
+	visit := func(entities map[K]*aoiEntity[K]) {
+		for _, o := range entities {
+			if o != e {
+				fn(o)
+			}
+		}
+	}
+
+	// Ranges larger than the occupied cells check every occupied cell instead
+	width, height := int64(maxCell.X)-int64(minCell.X)+1, int64(maxCell.Z)-int64(minCell.Z)+1
+	if width > int64(len(g.cells))/height {
+		for cell, entities := range g.cells {
+			if cell.X >= minCell.X && cell.X <= maxCell.X && cell.Z >= minCell.Z && cell.Z <= maxCell.Z {
+				visit(entities)
+			}
+		}
+		return
+	}
+	for x := int64(minCell.X); x <= int64(maxCell.X); x++ {
+		for z := int64(minCell.Z); z <= int64(maxCell.Z); z++ {
+			visit(g.cells[Coord{X: int32(x), Z: int32(z)}])
+		}
+	}
+}
+
+// crossNode represents an entity linked in the X list and the Z list
+type crossNode[K comparable] struct {
+	e    *aoiEntity[K]
+	prev [2]*crossNode[K] // Previous node on X and Z
+	next [2]*crossNode[K] // Next node on X and Z
+}
+
+// value returns the coordinate of the node on the axis, 0 for X and 1 for Z
+func (n *crossNode[K]) value(axis int) int32 {
+	if axis == 0 {
+		return n.e.coord.X
+	}
+	return n.e.coord.Z
+}
+
+// aoiCrossList represents the cross-linked list index
+type aoiCrossList[K comparable] struct {
+	nodes map[K]*crossNode[K]
+	heads [2]*crossNode[K] // First node on X and Z
+}
+
+func (l *aoiCrossList[K]) add(e *aoiEntity[K]) {
+	n := &crossNode[K]{e: e}
+	l.nodes[e.key] = n
+	for axis := range l.heads {
+		l.place(n, axis, nil, l.heads[axis])
+	}
+}
+
+func (l *aoiCrossList[K]) move(e *aoiEntity[K], _ Coord) {
+	n := l.nodes[e.key]
+	for axis := range l.heads {
+		prev, next := n.prev[axis], n.next[axis]
+		v := n.value(axis)
+		if (prev == nil || prev.value(axis) <= v) && (next == nil || next.value(axis) >= v) {
+			continue
+		}
+		l.unlink(n, axis)
+		l.place(n, axis, prev, next)
+	}
+}
+
+func (l *aoiCrossList[K]) remove(e *aoiEntity[K]) {
+	n := l.nodes[e.key]
+	for axis := range l.heads {
+		l.unlink(n, axis)
+	}
+	delete(l.nodes, e.key)
+}
+
+// place links the node in sorted position on the axis, searching from between prev and next
+func (l *aoiCrossList[K]) place(n *crossNode[K], axis int, prev, next *crossNode[K]) {
+	v := n.value(axis)
+	for prev != nil && prev.value(axis) > v {
+		prev, next = prev.prev[axis], prev
+	}
+	for next != nil && next.value(axis) < v {
+		prev, next = next, next.next[axis]
+	}
+	n.prev[axis], n.next[axis] = prev, next
+	if prev != nil {
+		prev.next[axis] = n
+	} else {
+		l.heads[axis] = n
+	}
+	if next != nil {
+		next.prev[axis] = n
+	}
+}
+
+// unlink removes the node from the list of the axis
+func (l *aoiCrossList[K]) unlink(n *crossNode[K], axis int) {
+	if n.prev[axis] != nil {
+		n.prev[axis].next[axis] = n.next[axis]
+	} else {
+		l.heads[axis] = n.next[axis]
+	}
+	if n.next[axis] != nil {
+		n.next[axis].prev[axis] = n.prev[axis]
+	}
+	n.prev[axis], n.next[axis] = nil, nil
+}
+
+// around walks the X and Z lists outwards from the entity one step at a time,
+// the axis whose range is exhausted first holds the fewest candidates.
+func (l *aoiCrossList[K]) around(e *aoiEntity[K], radius int32, fn func(o *aoiEntity[K])) {
+	n := l.nodes[e.key]
+	var found [2][]*aoiEntity[K]
+	walkers := [2][2]*crossNode[K]{
+		{n.prev[0], n.next[0]},
+		{n.prev[1], n.next[1]},
+	}
+	for {
+		for axis := range walkers {
+			center := int64(n.value(axis))
+			done := true
+			for dir, w := range walkers[axis] {
+				if w == nil || util.Abs(int64(w.value(axis))-center) > int64(radius) {
+					continue
+				}
+				found[axis] = append(found[axis], w.e)
+				if dir == 0 {
+					walkers[axis][dir] = w.prev[axis]
+				} else {
+					walkers[axis][dir] = w.next[axis]
+				}
+				done = false
+			}
+			if done {
+				for _, o := range found[axis] {
+					fn(o)
+				}
+				return
+			}
+		}
+	}
+}
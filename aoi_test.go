@@ -0,0 +1,146 @@
+package geo
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+)
+
+// compareAOIEvents orders events by watcher, target and type
+func compareAOIEvents(a, b AOIEvent[int]) int {
+	return cmp.Or(cmp.Compare(a.Watcher, b.Watcher), cmp.Compare(a.Target, b.Target), cmp.Compare(a.Type, b.Type))
+}
+
+func TestAOIEvents(t *testing.T) {
+	type step struct {
+		name   string
+		do     func(a *AOI[int]) bool
+		events []AOIEvent[int]
+	}
+	steps := []step{
+		{
+			name:   "enter alone",
+			do:     func(a *AOI[int]) bool { return a.Enter(1, Coord{X: 0, Z: 0}, 100) },
+			events: nil,
+		},
+		{
+			name: "enter in view",
+			do:   func(a *AOI[int]) bool { return a.Enter(2, Coord{X: 50, Z: 0}, 100) },
+			events: []AOIEvent[int]{
+				{Type: AOIEnter, Watcher: 1, Target: 2, Coord: Coord{X: 50, Z: 0}},
+				{Type: AOIEnter, Watcher: 2, Target: 1, Coord: Coord{X: 0, Z: 0}},
+			},
+		},
+		{
+			name: "enter with a larger radius",
+			do:   func(a *AOI[int]) bool { return a.Enter(3, Coord{X: 300, Z: 0}, 1000) },
+			events: []AOIEvent[int]{
+				{Type: AOIEnter, Watcher: 3, Target: 1, Coord: Coord{X: 0, Z: 0}},
+				{Type: AOIEnter, Watcher: 3, Target: 2, Coord: Coord{X: 50, Z: 0}},
+			},
+		},
+		{
+			name:   "enter twice",
+			do:     func(a *AOI[int]) bool { return !a.Enter(1, Coord{X: 10, Z: 10}, 100) },
+			events: nil,
+		},
+		{
+			name: "move in view",
+			do:   func(a *AOI[int]) bool { return a.Move(2, Coord{X: 80, Z: 0}) },
+			events: []AOIEvent[int]{
+				{Type: AOIMove, Watcher: 1, Target: 2, Coord: Coord{X: 80, Z: 0}},
+				{Type: AOIMove, Watcher: 3, Target: 2, Coord: Coord{X: 80, Z: 0}},
+			},
+		},
+		{
+			name: "move out of view",
+			do:   func(a *AOI[int]) bool { return a.Move(2, Coord{X: 500, Z: 0}) },
+			events: []AOIEvent[int]{
+				{Type: AOILeave, Watcher: 1, Target: 2, Coord: Coord{X: 500, Z: 0}},
+				{Type: AOILeave, Watcher: 2, Target: 1, Coord: Coord{X: 0, Z: 0}},
+				{Type: AOIMove, Watcher: 3, Target: 2, Coord: Coord{X: 500, Z: 0}},
+			},
+		},
+		{
+			name:   "move unknown",
+			do:     func(a *AOI[int]) bool { return !a.Move(4, Coord{X: 0, Z: 0}) },
+			events: nil,
+		},
+		{
+			name: "leave",
+			do:   func(a *AOI[int]) bool { return a.Leave(3) },
+			events: []AOIEvent[int]{
+				{Type: AOILeave, Watcher: 3, Target: 1, Coord: Coord{X: 0, Z: 0}},
+				{Type: AOILeave, Watcher: 3, Target: 2, Coord: Coord{X: 500, Z: 0}},
+			},
+		},
+		{
+			name:   "leave twice",
+			do:     func(a *AOI[int]) bool { return !a.Leave(3) },
+			events: nil,
+		},
+	}
+
+	aois := []struct {
+		name string
+		new  func(callback func(AOIEvent[int])) *AOI[int]
+	}{
+		{name: "grid", new: func(callback func(AOIEvent[int])) *AOI[int] { return NewGridAOI(100, callback) }},
+		{name: "cross list", new: NewCrossListAOI[int]},
+	}
+	for _, tt := range aois {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []AOIEvent[int]
+			a := tt.new(func(event AOIEvent[int]) {
+				events = append(events, event)
+			})
+			for _, s := range steps {
+				events = events[:0]
+				if !s.do(a) {
+					t.Fatalf("%s: unexpected result", s.name)
+				}
+				slices.SortFunc(events, compareAOIEvents)
+				if !slices.Equal(events, s.events) {
+					t.Errorf("%s: events = %v, want %v", s.name, events, s.events)
+				}
+			}
+			if a.Len() != 2 {
+				t.Errorf("Len() = %d, want 2", a.Len())
+			}
+			if view := a.View(1); len(view) != 0 {
+				t.Errorf("View(1) = %v, want empty", view)
+			}
+		})
+	}
+}
+
+func TestAOICoordinateLimits(t *testing.T) {
+	aois := []struct {
+		name string
+		new  func(callback func(AOIEvent[int])) *AOI[int]
+	}{
+		{name: "grid of size 1", new: func(callback func(AOIEvent[int])) *AOI[int] { return NewGridAOI(1, callback) }},
+		{name: "grid of size 3", new: func(callback func(AOIEvent[int])) *AOI[int] { return NewGridAOI(3, callback) }},
+		{name: "cross list", new: NewCrossListAOI[int]},
+	}
+	for _, tt := range aois {
+		t.Run(tt.name, func(t *testing.T) {
+			var events int
+			a := tt.new(func(AOIEvent[int]) { events++ })
+			a.Enter(1, Coord{X: math.MaxInt32 - 1, Z: math.MaxInt32 - 1}, 10)
+			a.Enter(2, Coord{X: math.MaxInt32, Z: math.MaxInt32}, 10)
+			a.Enter(3, Coord{X: math.MinInt32, Z: math.MinInt32}, 10)
+			a.Enter(4, Coord{X: math.MinInt32 + 1, Z: math.MinInt32}, 10)
+			a.Enter(5, Coord{X: math.MaxInt32, Z: 0}, 10)
+			if events != 4 {
+				t.Errorf("Enter() near the limits sent %d events, want 4", events)
+			}
+			// The widest view reaches the edge of the plane but not its corners
+			a.Enter(6, Coord{X: 0, Z: 0}, math.MaxInt32)
+			if view := a.View(6); !slices.Equal(view, []int{5}) {
+				t.Errorf("View(6) = %v, want [5]", view)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ func NewBorder(x, z, width, height int32) Border {
 }
 
 // RectLocation determines the boundary position of a rectangle
+// Quadrants include the center lines, so a rectangle touching a center line belongs to both sides
 func (b *Border) RectLocation(minX, minZ, maxX, maxZ int32) LocationState {
 	if minX > b.X+b.Width ||
 		minZ > b.Z+b.Height ||
@@ -43,11 +44,11 @@ func (b *Border) RectLocation(minX, minZ, maxX, maxZ int32) LocationState {
 			location |= LeftBottom
 		}
 	}
-	if maxX > centerZ {
-		if maxZ > centerZ {
+	if maxX >= centerX {
+		if maxZ >= centerZ {
 			location |= RightTop
 		}
-		if minZ < centerZ {
+		if minZ <= centerZ {
 			location |= RightBottom
 		}
 	}
@@ -0,0 +1,230 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var (
+	// ErrNonManifoldEdge is returned when an edge is shared by more than two triangles
+	ErrNonManifoldEdge = errors.New("geo: non-manifold edge")
+	// ErrDegenerateTriangle is returned when a triangle has repeated or collinear vertices
+	ErrDegenerateTriangle = errors.New("geo: degenerate triangle")
+	// ErrVertexIndexOutOfRange is returned when an index triple refers to a missing coordinate
+	ErrVertexIndexOutOfRange = errors.New("geo: vertex index out of range")
+)
+
+// MeshBuilder welds triangle soup vertices and generates edges and adjacency
+type MeshBuilder struct {
+	WeldDistance int32 // Vertices within this distance are welded, 0 only welds identical coordinates
+
+	vertices  []Vertice         // Welded vertices, Index equals position
+	cells     map[Coord][]int32 // Weld grid cell to vertex indices
+	triangles []*Triangle       // Triangles added so far
+}
+
+// NewMeshBuilder creates a new mesh builder
+func NewMeshBuilder(weldDistance int32) *MeshBuilder {
+	return &MeshBuilder{
+		WeldDistance: weldDistance,
+		cells:        make(map[Coord][]int32),
+	}
+}
+
+// AddVertice adds a coordinate and returns the welded vertex
+func (b *MeshBuilder) AddVertice(p Coord) Vertice {
+	if v, ok := b.findVertice(p); ok {
+		return v
+	}
+	v := Vertice{Index: int32(len(b.vertices)), Coord: p}
+	b.vertices = append(b.vertices, v)
+	cell := b.cellOf(p)
+	b.cells[cell] = append(b.cells[cell], v.Index)
+	return v
+}
+
+// findVertice returns the added vertex the coordinate welds to
+func (b *MeshBuilder) findVertice(p Coord) (Vertice, bool) {
+	cell := b.cellOf(p)
+	if b.WeldDistance > 0 {
+		for x := cell.X - 1; x <= cell.X+1; x++ {
+			for z := cell.Z - 1; z <= cell.Z+1; z++ {
+				for _, index := range b.cells[Coord{X: x, Z: z}] {
+					if b.welds(b.vertices[index].Coord, p) {
+						return b.vertices[index], true
+					}
+				}
+			}
+		}
+	} else {
+		for _, index := range b.cells[cell] {
+			if b.welds(b.vertices[index].Coord, p) {
+				return b.vertices[index], true
+			}
+		}
+	}
+	return Vertice{}, false
+}
+
+// cellOf returns the weld grid cell of the coordinate
+func (b *MeshBuilder) cellOf(p Coord) Coord {
+	size := max(b.WeldDistance, 1)
+	return Coord{X: floorDiv(p.X, size), Z: floorDiv(p.Z, size)}
+}
+
+// welds checks if two coordinates weld into one vertex
+func (b *MeshBuilder) welds(p1, p2 Coord) bool {
+	if b.WeldDistance > 0 {
+		limit := float64(b.WeldDistance) * float64(b.WeldDistance)
+		return CalDstCoordToCoordWithoutSqrt(p1, p2) <= limit
+	}
+	return p1 == p2
+}
+
+// AddTriangle welds the vertices of a copy of the triangle by coordinate and adds the copy to the mesh
+// The copy gets the welded vertex indices, its insertion order as index and its vertices sorted
+// clockwise as expected by Triangle.IsCoordInside and GetVectors, the given triangle is left untouched.
+// Vertices are only welded once the triangle is known not to be degenerate,
+// so a rejected triangle adds no vertices.
+func (b *MeshBuilder) AddTriangle(t *Triangle) error {
+	if len(t.Vertices) != 3 {
+		return fmt.Errorf("%w: triangle %d has %d vertices", ErrDegenerateTriangle, t.Index, len(t.Vertices))
+	}
+	// New vertices get index -1 until they are added
+	vertices := make([]Vertice, 3)
+	for i := range vertices {
+		v, ok := b.findVertice(t.Vertices[i].Coord)
+		if !ok {
+			v = Vertice{Index: -1, Coord: t.Vertices[i].Coord}
+		}
+		vertices[i] = v
+	}
+	area := cross(vertices[1].Coord, vertices[2].Coord, vertices[0].Coord)
+	for i := range vertices {
+		v1, v2 := vertices[i], vertices[(i+1)%3]
+		if v1.Index >= 0 && v1.Index == v2.Index || v1.Index < 0 && v2.Index < 0 && b.welds(v1.Coord, v2.Coord) {
+			area = 0
+		}
+	}
+	if area == 0 {
+		return fmt.Errorf("%w: triangle %d", ErrDegenerateTriangle, t.Index)
+	}
+	for i, v := range vertices {
+		if v.Index < 0 {
+			vertices[i] = b.AddVertice(v.Coord)
+		}
+	}
+	// Counter-clockwise triangles are reversed
+	if area > 0 {
+		vertices[1], vertices[2] = vertices[2], vertices[1]
+	}
+
+	tri := *t
+	tri.Index = int32(len(b.triangles))
+	tri.Vertices = vertices
+	b.triangles = append(b.triangles, &tri)
+	return nil
+}
+
+// AddIndexed adds triangles given by raw coordinates and index triples
+// Triangles with an out of range index or rejected by AddTriangle are skipped,
+// the others are added and the errors of the skipped ones are joined.
+func (b *MeshBuilder) AddIndexed(coords []Coord, indices [][3]int32) error {
+	var errs []error
+	for n, tri := range indices {
+		t := &Triangle{Vertices: make([]Vertice, 3)}
+		var err error
+		for i, index := range tri {
+			if index < 0 || int(index) >= len(coords) {
+				err = fmt.Errorf("%w: index %d of triangle %d", ErrVertexIndexOutOfRange, index, n)
+				break
+			}
+			t.Vertices[i] = Vertice{Index: index, Coord: coords[index]}
+		}
+		if err == nil {
+			err = b.AddTriangle(t)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetVertices returns the welded vertices
+func (b *MeshBuilder) GetVertices() []Vertice {
+	return b.vertices
+}
+
+// Build generates edges for all added triangles
+// Returns the triangles, the edges and the errors of non-manifold edges
+func (b *MeshBuilder) Build() ([]*Triangle, []Edge, error) {
+	edges, err := BuildEdges(b.triangles)
+	return b.triangles, edges, err
+}
+
+// BuildEdges generates edges from triangles with welded vertex indices
+// Fills Triangle.EdgeKeys, Triangle.EdgeKeyString and the deprecated Triangle.EdgeIDs, every edge gets its adjacent triangles
+// and edges shared by exactly two triangles are marked as adjacency edges.
+// Edges shared by more than two triangles are reported as ErrNonManifoldEdge errors
+// and are not marked as adjacency edges.
+func BuildEdges(triangles []*Triangle) ([]Edge, error) {
+	var edges []Edge
+	lookup := make(map[EdgeKey]int)
+
+	for _, t := range triangles {
+		t.EdgeIDs = make([]int32, 0, 3)
+		t.EdgeKeyString = make([]string, 0, 3)
+		t.EdgeKeys = make([]EdgeKey, 0, 3)
+		for i := range t.Vertices {
+			v1 := t.Vertices[i]
+			v2 := t.Vertices[(i+1)%len(t.Vertices)]
+			key := GenEdgeKey64(v1.Index, v2.Index)
+
+			index, exist := lookup[key]
+			if !exist {
+				index = len(edges)
+				lookup[key] = index
+				edges = append(edges, Edge{
+					WtCoord:  CalMidCoord(v1.Coord, v2.Coord),
+					Vertices: [2]Vertice{v1, v2},
+				})
+			}
+			edges[index].AdjacenctTriangles = append(edges[index].AdjacenctTriangles, t)
+
+			t.EdgeKeys = append(t.EdgeKeys, key)
+			t.EdgeIDs = append(t.EdgeIDs, key.Legacy())
+			i1, i2 := key.Indices()
+			t.EdgeKeyString = append(t.EdgeKeyString, strconv.Itoa(int(i1))+"_"+strconv.Itoa(int(i2)))
+		}
+	}
+
+	var errs []error
+	for i := range edges {
+		e := &edges[i]
+		switch n := len(e.AdjacenctTriangles); {
+		case n == 2:
+			e.IsAdjacency = true
+		case n > 2:
+			errs = append(errs, fmt.Errorf("%w: vertices %d and %d shared by %d triangles",
+				ErrNonManifoldEdge, e.Vertices[0].Index, e.Vertices[1].Index, n))
+		}
+	}
+	return edges, errors.Join(errs...)
+}
+
+// clampInt32 limits v to the int32 range
+func clampInt32(v int64) int32 {
+	return int32(max(math.MinInt32, min(math.MaxInt32, v)))
+}
+
+// floorDiv returns a/b rounded towards negative infinity
+func floorDiv(a, b int32) int32 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
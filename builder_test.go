@@ -0,0 +1,138 @@
+package geo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// newTriangle creates a triangle with unindexed vertices at the coordinates
+func newTriangle(coords ...Coord) *Triangle {
+	t := &Triangle{}
+	for _, c := range coords {
+		t.Vertices = append(t.Vertices, Vertice{Coord: c})
+	}
+	return t
+}
+
+func TestMeshBuilderAddTriangle(t *testing.T) {
+	b := NewMeshBuilder(5)
+	if err := b.AddTriangle(newTriangle(Coord{X: 0, Z: 0}, Coord{X: 0, Z: 100}, Coord{X: 100, Z: 0})); err != nil {
+		t.Fatal(err)
+	}
+
+	// Counter-clockwise, with two corners within the weld distance of the first triangle
+	tri := newTriangle(Coord{X: 102, Z: 1}, Coord{X: 100, Z: 100}, Coord{X: 1, Z: 99})
+	tri.Index = 42
+	orig := slices.Clone(tri.Vertices)
+	if err := b.AddTriangle(tri); err != nil {
+		t.Fatal(err)
+	}
+	if tri.Index != 42 || !slices.Equal(tri.Vertices, orig) {
+		t.Errorf("AddTriangle() changed the given triangle to %+v", tri)
+	}
+
+	want := []Vertice{
+		{Index: 0, Coord: Coord{X: 0, Z: 0}},
+		{Index: 1, Coord: Coord{X: 0, Z: 100}},
+		{Index: 2, Coord: Coord{X: 100, Z: 0}},
+		{Index: 3, Coord: Coord{X: 100, Z: 100}},
+	}
+	if got := b.GetVertices(); !slices.Equal(got, want) {
+		t.Errorf("GetVertices() = %v, want %v", got, want)
+	}
+	triangles, _, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	added := triangles[1]
+	if added == tri || added.Index != 1 {
+		t.Errorf("AddTriangle() added %p with index %d, want a copy with index 1", added, added.Index)
+	}
+	if got := []int32{added.Vertices[0].Index, added.Vertices[1].Index, added.Vertices[2].Index}; !slices.Equal(got, []int32{2, 1, 3}) {
+		t.Errorf("AddTriangle() vertex indices = %v, want clockwise [2 1 3]", got)
+	}
+}
+
+func TestMeshBuilderAddDegenerateTriangle(t *testing.T) {
+	tests := []struct {
+		name   string
+		coords []Coord
+	}{
+		{name: "two vertices", coords: []Coord{{X: 0, Z: 0}, {X: 100, Z: 0}}},
+		{name: "collinear", coords: []Coord{{X: 200, Z: 0}, {X: 300, Z: 0}, {X: 400, Z: 0}}},
+		{name: "repeated", coords: []Coord{{X: 200, Z: 0}, {X: 300, Z: 50}, {X: 200, Z: 0}}},
+		{name: "new vertices welded together", coords: []Coord{{X: 500, Z: 500}, {X: 503, Z: 500}, {X: 600, Z: 700}}},
+		{name: "existing vertices welded together", coords: []Coord{{X: 0, Z: 0}, {X: 2, Z: 2}, {X: 600, Z: 700}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewMeshBuilder(5)
+			if err := b.AddTriangle(newTriangle(Coord{X: 0, Z: 0}, Coord{X: 0, Z: 100}, Coord{X: 100, Z: 0})); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.AddTriangle(newTriangle(tt.coords...)); !errors.Is(err, ErrDegenerateTriangle) {
+				t.Errorf("AddTriangle() error = %v, want %v", err, ErrDegenerateTriangle)
+			}
+			if n := len(b.GetVertices()); n != 3 {
+				t.Errorf("AddTriangle() left %d vertices, want 3", n)
+			}
+		})
+	}
+}
+
+func TestMeshBuilderBuild(t *testing.T) {
+	b := NewMeshBuilder(0)
+	coords := []Coord{{X: 0, Z: 0}, {X: 100, Z: 0}, {X: 100, Z: 100}, {X: 0, Z: 100}, {X: 50, Z: -100}}
+	if err := b.AddIndexed(coords, [][3]int32{{0, 1, 2}, {0, 2, 3}}); err != nil {
+		t.Fatal(err)
+	}
+	triangles, edges, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triangles) != 2 || len(edges) != 5 {
+		t.Fatalf("Build() = %d triangles and %d edges, want 2 and 5", len(triangles), len(edges))
+	}
+	for _, e := range edges {
+		want := 1
+		if e.GenKey64() == GenEdgeKey64(0, 2) {
+			want = 2
+		}
+		if e.IsAdjacency != (want == 2) || len(e.AdjacenctTriangles) != want {
+			t.Errorf("Build() edge %v adjacency = %v with %d triangles", e.GenKey64(), e.IsAdjacency, len(e.AdjacenctTriangles))
+		}
+	}
+	for _, tri := range triangles {
+		if !slices.Contains(tri.EdgeKeys, GenEdgeKey64(0, 2)) {
+			t.Errorf("Build() triangle %d edge keys = %v, want the shared edge", tri.Index, tri.EdgeKeys)
+		}
+	}
+
+	// A third triangle on the shared edge makes it non-manifold
+	if err := b.AddIndexed(coords, [][3]int32{{0, 2, 4}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := b.Build(); !errors.Is(err, ErrNonManifoldEdge) {
+		t.Errorf("Build() error = %v, want %v", err, ErrNonManifoldEdge)
+	}
+}
+
+func TestMeshBuilderAddIndexed(t *testing.T) {
+	b := NewMeshBuilder(0)
+	coords := []Coord{{X: 0, Z: 0}, {X: 100, Z: 0}, {X: 100, Z: 100}, {X: 0, Z: 100}}
+	err := b.AddIndexed(coords, [][3]int32{{0, 1, 2}, {0, 1, 9}, {0, -1, 2}, {0, 1, 1}, {0, 2, 3}})
+	if !errors.Is(err, ErrVertexIndexOutOfRange) || !errors.Is(err, ErrDegenerateTriangle) {
+		t.Errorf("AddIndexed() error = %v, want %v and %v", err, ErrVertexIndexOutOfRange, ErrDegenerateTriangle)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 3 {
+		t.Errorf("AddIndexed() joined %d errors, want 3", n)
+	}
+	triangles, _, buildErr := b.Build()
+	if buildErr != nil {
+		t.Fatal(buildErr)
+	}
+	if len(triangles) != 2 || len(b.GetVertices()) != 4 {
+		t.Errorf("AddIndexed() added %d triangles and %d vertices, want 2 and 4", len(triangles), len(b.GetVertices()))
+	}
+}
@@ -0,0 +1,102 @@
+package geo
+
+import (
+	"math"
+)
+
+// Capsule represents a segment swept by a circle, used for characters and beams
+type Capsule struct {
+	Segment Segment // Core segment of the capsule
+	Radius  int32   // Radius around the core segment
+}
+
+// NewCapsule creates a new capsule around the segment from a to b
+func NewCapsule(a, b Coord, radius int32) Capsule {
+	return Capsule{
+		Segment: NewSegment(a, b),
+		Radius:  radius,
+	}
+}
+
+// CalCoordDst calculates the distance from a point to the capsule, 0 when the point is inside
+func (c *Capsule) CalCoordDst(p Coord) float64 {
+	return max(c.Segment.CalCoordDst(p)-float64(c.Radius), 0)
+}
+
+// IsCoordInside checks if point is inside the capsule, points on the boundary are inside
+func (c *Capsule) IsCoordInside(p Coord) bool {
+	return c.Segment.CalCoordDst(p) <= float64(c.Radius)
+}
+
+// ClosestCoord returns the point of the capsule closest to the given point
+// Points inside the capsule are returned as is
+func (c *Capsule) ClosestCoord(p Coord) Coord {
+	q := c.Segment.ClosestCoord(p)
+	vec := NewVector(q, p)
+	length := vec.Length()
+	if length <= float64(c.Radius) {
+		return p
+	}
+	ratio := float64(c.Radius) / length
+	return Coord{
+		X: q.X + int32(math.Round(float64(vec.X)*ratio)),
+		Z: q.Z + int32(math.Round(float64(vec.Z)*ratio)),
+	}
+}
+
+// IsInterCircle checks if the capsule intersects with circle
+func (c *Capsule) IsInterCircle(circle *Circle) bool {
+	return c.Segment.CalCoordDst(circle.Center) <= float64(c.Radius)+float64(circle.Radius)
+}
+
+// IsInterSegment checks if the capsule intersects with line segment
+func (c *Capsule) IsInterSegment(s *Segment) bool {
+	return c.Segment.IsInterSegment(s) || c.Segment.CalSegmentDst(s) <= float64(c.Radius)
+}
+
+// IsInterCapsule checks if two capsules intersect
+func (c *Capsule) IsInterCapsule(o *Capsule) bool {
+	return c.Segment.CalSegmentDst(&o.Segment) <= float64(c.Radius)+float64(o.Radius)
+}
+
+// IsInterConvex checks if the capsule intersects with a convex polygon
+func (c *Capsule) IsInterConvex(p ConvexPolygon) bool {
+	coords := p.GetCoords()
+	if NewSimplePolygon(coords, 0).IsCoordInside(c.Segment.A) {
+		return true
+	}
+	r := float64(c.Radius)
+	for i := range coords {
+		edge := NewSegment(coords[i], coords[(i+1)%len(coords)])
+		if c.Segment.CalSegmentDst(&edge) <= r {
+			return true
+		}
+	}
+	return false
+}
+
+// Support returns the farthest point of the capsule in the direction
+func (c *Capsule) Support(dir Vector) Coord {
+	end := c.Segment.A
+	if a, b := NewVectorByCoord(c.Segment.A), NewVectorByCoord(c.Segment.B); b.Dot(&dir) > a.Dot(&dir) {
+		end = c.Segment.B
+	}
+	circle := NewCirCle(end, c.Radius)
+	return circle.Support(dir)
+}
+
+// ToRect returns the bounding rectangle of the capsule
+// Returns the minimum and maximum X,Z coordinates of the bounding rectangle
+func (c *Capsule) ToRect() (minX, minZ, maxX, maxZ int32) {
+	minX = min(c.Segment.A.X, c.Segment.B.X) - c.Radius
+	minZ = min(c.Segment.A.Z, c.Segment.B.Z) - c.Radius
+	maxX = max(c.Segment.A.X, c.Segment.B.X) + c.Radius
+	maxZ = max(c.Segment.A.Z, c.Segment.B.Z) + c.Radius
+	return
+}
+
+// GetLocationToBorder returns the relative position between capsule and border
+func (c *Capsule) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := c.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// flatCapsule is a capsule of radius 20 around the segment from (0, 0) to (100, 0)
+var flatCapsule = NewCapsule(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}, 20)
+
+func TestCapsuleCoord(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Coord
+		inside  bool
+		dst     float64
+		closest Coord
+	}{
+		{name: "on the core", p: Coord{X: 50, Z: 0}, inside: true, dst: 0, closest: Coord{X: 50, Z: 0}},
+		{name: "on the side", p: Coord{X: 50, Z: 20}, inside: true, dst: 0, closest: Coord{X: 50, Z: 20}},
+		{name: "beside", p: Coord{X: 50, Z: -50}, inside: false, dst: 30, closest: Coord{X: 50, Z: -20}},
+		{name: "on the cap", p: Coord{X: 112, Z: 16}, inside: true, dst: 0, closest: Coord{X: 112, Z: 16}},
+		{name: "past the cap", p: Coord{X: 130, Z: 40}, inside: false, dst: 30, closest: Coord{X: 112, Z: 16}},
+		{name: "behind the start", p: Coord{X: -21, Z: 0}, inside: false, dst: 1, closest: Coord{X: -20, Z: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flatCapsule.IsCoordInside(tt.p); got != tt.inside {
+				t.Errorf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.inside)
+			}
+			if got := flatCapsule.CalCoordDst(tt.p); math.Abs(got-tt.dst) > 1e-9 {
+				t.Errorf("CalCoordDst(%v) = %v, want %v", tt.p, got, tt.dst)
+			}
+			if got := flatCapsule.ClosestCoord(tt.p); got != tt.closest {
+				t.Errorf("ClosestCoord(%v) = %v, want %v", tt.p, got, tt.closest)
+			}
+		})
+	}
+}
+
+func TestCapsuleIsInter(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape Shape
+		want  bool
+	}{
+		{name: "circle touching the side", shape: ptr(NewCirCle(Coord{X: 50, Z: 30}, 10)), want: true},
+		{name: "circle beside", shape: ptr(NewCirCle(Coord{X: 50, Z: 31}, 10)), want: false},
+		{name: "circle at the cap", shape: ptr(NewCirCle(Coord{X: 130, Z: 0}, 10)), want: true},
+		{name: "segment crossing the core", shape: ptr(NewSegment(Coord{X: 50, Z: -50}, Coord{X: 50, Z: 50})), want: true},
+		{name: "segment along the side", shape: ptr(NewSegment(Coord{X: 0, Z: 20}, Coord{X: 100, Z: 20})), want: true},
+		{name: "segment past the cap", shape: ptr(NewSegment(Coord{X: 121, Z: -50}, Coord{X: 121, Z: 50})), want: false},
+		{name: "parallel capsule", shape: ptr(NewCapsule(Coord{X: 0, Z: 35}, Coord{X: 100, Z: 35}, 15)), want: true},
+		{name: "distant capsule", shape: ptr(NewCapsule(Coord{X: 0, Z: 36}, Coord{X: 100, Z: 36}, 15)), want: false},
+		{name: "rectangle around the core", shape: ptr(NewRectangle(-50, -50, 300, 100)), want: true},
+		{name: "rectangle in reach", shape: ptr(NewRectangle(40, 20, 20, 20)), want: true},
+		{name: "rectangle out of reach", shape: ptr(NewRectangle(40, 21, 20, 20)), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Intersects(&flatCapsule, tt.shape); got != tt.want {
+				t.Errorf("Intersects(%v) = %v, want %v", tt.shape, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapsuleBounds(t *testing.T) {
+	c := NewCapsule(Coord{X: 100, Z: 50}, Coord{X: 0, Z: 0}, 10)
+	if minX, minZ, maxX, maxZ := c.ToRect(); minX != -10 || minZ != -10 || maxX != 110 || maxZ != 60 {
+		t.Errorf("ToRect() = %d, %d, %d, %d, want -10, -10, 110, 60", minX, minZ, maxX, maxZ)
+	}
+	tests := []struct {
+		dir  Vector
+		want Coord
+	}{
+		{dir: Vector{X: 1, Z: 0}, want: Coord{X: 110, Z: 50}},
+		{dir: Vector{X: -1, Z: 0}, want: Coord{X: -10, Z: 0}},
+		{dir: Vector{X: 0, Z: 1}, want: Coord{X: 100, Z: 60}},
+		{dir: Vector{X: -3, Z: -4}, want: Coord{X: -6, Z: -8}},
+	}
+	for _, tt := range tests {
+		if got := c.Support(tt.dir); got != tt.want {
+			t.Errorf("Support(%v) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+}
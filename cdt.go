@@ -0,0 +1,493 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrInvalidRing is returned when a polygon ring has less than three distinct points
+	ErrInvalidRing = errors.New("geo: invalid polygon ring")
+	// ErrConstraintCross is returned when constraint edges cross each other
+	ErrConstraintCross = errors.New("geo: constraint edges cross")
+	// ErrConstraintNotInserted is returned when edge flips fail to recover a constraint edge
+	ErrConstraintNotInserted = errors.New("geo: constraint edge not inserted")
+)
+
+// ConstrainedDelaunay triangulates a walkable area with the constrained Delaunay triangulation
+// outer: boundary ring of the walkable area
+// holes: obstacle rings inside the boundary
+// Rings may be in either winding order, every ring edge is kept as a constraint edge.
+// Returns triangles sorted clockwise as expected by Triangle.IsCoordInside and GetVectors,
+// Vertice.Index numbers the distinct ring points in order of appearance starting from the outer ring.
+// Use BuildEdges to generate the edges of the result.
+func ConstrainedDelaunay(outer []Coord, holes [][]Coord) ([]*Triangle, error) {
+	rings := append([][]Coord{outer}, holes...)
+	d := &cdt{
+		lookup: make(map[Coord]int),
+		edges:  make(map[[2]int]int),
+		fixed:  make(map[[2]int]int),
+	}
+
+	// Distinct points and constraint edges
+	var constraints [][2]int
+	for i, ring := range rings {
+		indices := make([]int, 0, len(ring))
+		distinct := make(map[int]bool, len(ring))
+		for _, p := range ring {
+			index := d.addPoint(p)
+			indices = append(indices, index)
+			distinct[index] = true
+		}
+		if len(distinct) < 3 {
+			return nil, fmt.Errorf("%w: ring %d", ErrInvalidRing, i)
+		}
+		for j, index := range indices {
+			constraints = append(constraints, [2]int{index, indices[(j+1)%len(indices)]})
+		}
+	}
+
+	d.triangulate()
+	for _, c := range constraints {
+		if err := d.insertConstraint(c[0], c[1]); err != nil {
+			return nil, err
+		}
+	}
+	d.legalize()
+
+	inside := d.classify()
+	var triangles []*Triangle
+	for ti, t := range d.tris {
+		if !inside[ti] {
+			continue
+		}
+		// Internal triangles are counter-clockwise
+		triangles = append(triangles, &Triangle{
+			Index: int32(len(triangles)),
+			Vertices: []Vertice{
+				{Index: int32(t.v[0]), Coord: d.coords[t.v[0]]},
+				{Index: int32(t.v[2]), Coord: d.coords[t.v[2]]},
+				{Index: int32(t.v[1]), Coord: d.coords[t.v[1]]},
+			},
+		})
+	}
+	return triangles, nil
+}
+
+// cdtTriangle represents a counter-clockwise triangle of the triangulation
+type cdtTriangle struct {
+	v     [3]int // Point indices
+	alive bool   // Whether the triangle is still part of the triangulation
+}
+
+// cdt holds the state of a constrained Delaunay triangulation
+type cdt struct {
+	coords []Coord        // Input points
+	points [][2]float64   // Input points followed by the three super triangle points
+	lookup map[Coord]int  // Coordinate to point index
+	tris   []cdtTriangle  // Triangles, dead ones are skipped
+	last   int            // Last stored triangle, where point location starts walking
+	edges  map[[2]int]int // Directed edge to the triangle containing it
+	fixed  map[[2]int]int // Constraint edges keyed by sorted point indices, with the number of rings holding them
+}
+
+// addPoint adds a distinct point and returns its index
+func (d *cdt) addPoint(p Coord) int {
+	if index, exist := d.lookup[p]; exist {
+		return index
+	}
+	index := len(d.coords)
+	d.lookup[p] = index
+	d.coords = append(d.coords, p)
+	d.points = append(d.points, [2]float64{float64(p.X), float64(p.Z)})
+	return index
+}
+
+// triangulate builds the Delaunay triangulation of all points with Bowyer-Watson
+func (d *cdt) triangulate() {
+	minX, minZ := d.points[0][0], d.points[0][1]
+	maxX, maxZ := minX, minZ
+	for _, p := range d.points {
+		minX, maxX = min(minX, p[0]), max(maxX, p[0])
+		minZ, maxZ = min(minZ, p[1]), max(maxZ, p[1])
+	}
+	size := max(maxX-minX, maxZ-minZ, 1)
+	cx, cz := (minX+maxX)/2, (minZ+maxZ)/2
+
+	// Super triangle containing every point
+	n := len(d.points)
+	d.points = append(d.points,
+		[2]float64{cx - 20*size, cz - 10*size},
+		[2]float64{cx + 20*size, cz - 10*size},
+		[2]float64{cx, cz + 20*size},
+	)
+	d.addTriangle(n, n+1, n+2)
+
+	for i := 0; i < n; i++ {
+		d.insertPoint(i)
+	}
+}
+
+// insertPoint inserts a point by re-triangulating the cavity of triangles whose circumcircle contains it
+func (d *cdt) insertPoint(pi int) {
+	p := d.points[pi]
+	start := d.locate(p)
+	if start < 0 {
+		return
+	}
+
+	// The cavity keeps the discovery order so that the triangulation does not depend on map iteration
+	bad := map[int]bool{start: true}
+	cavity := []int{start}
+	stack := []int{start}
+	for len(stack) > 0 {
+		ti := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		t := d.tris[ti]
+		for k := 0; k < 3; k++ {
+			a, b := t.v[k], t.v[(k+1)%3]
+			ni, exist := d.edges[[2]int{b, a}]
+			if !exist || bad[ni] {
+				continue
+			}
+			// Points on an edge of the containing triangle always split the neighbor
+			if (ti == start && d.orient(a, b, p) == 0) || d.inCircle(ni, p) > 0 {
+				bad[ni] = true
+				cavity = append(cavity, ni)
+				stack = append(stack, ni)
+			}
+		}
+	}
+
+	var boundary [][2]int
+	for _, ti := range cavity {
+		t := d.tris[ti]
+		for k := 0; k < 3; k++ {
+			a, b := t.v[k], t.v[(k+1)%3]
+			if ni, exist := d.edges[[2]int{b, a}]; !exist || !bad[ni] {
+				boundary = append(boundary, [2]int{a, b})
+			}
+		}
+	}
+	for _, ti := range cavity {
+		d.removeTriangle(ti)
+	}
+	for _, e := range boundary {
+		d.addTriangle(e[0], e[1], pi)
+	}
+}
+
+// locate returns a triangle containing p, or -1
+// The walk starts from the last stored triangle and crosses the edges having p on their right,
+// which is short as consecutive ring points are close to each other.
+// Reference: https://doi.org/10.1142/S0129054102001047
+func (d *cdt) locate(p [2]float64) int {
+	ti := d.last
+	for step := 0; step < len(d.tris) && d.tris[ti].alive; step++ {
+		t := d.tris[ti]
+		next := ti
+		for k := 0; k < 3; k++ {
+			// Rotating the first tested edge keeps the walk from cycling on degenerate triangles
+			a, b := t.v[(k+step)%3], t.v[(k+step+1)%3]
+			if d.orient(a, b, p) < 0 {
+				ni, exist := d.edges[[2]int{b, a}]
+				if !exist {
+					return -1
+				}
+				next = ni
+				break
+			}
+		}
+		if next == ti {
+			return ti
+		}
+		ti = next
+	}
+	for i, t := range d.tris {
+		if t.alive && d.orient(t.v[0], t.v[1], p) >= 0 && d.orient(t.v[1], t.v[2], p) >= 0 && d.orient(t.v[2], t.v[0], p) >= 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertConstraint forces the edge between two points into the triangulation by flipping crossing edges
+func (d *cdt) insertConstraint(a, b int) error {
+	if a == b {
+		return nil
+	}
+	key := sortedPair(a, b)
+	if _, exist := d.edges[[2]int{a, b}]; exist {
+		d.fixed[key]++
+		return nil
+	}
+	if _, exist := d.edges[[2]int{b, a}]; exist {
+		d.fixed[key]++
+		return nil
+	}
+
+	queue, split := d.crossedEdges(a, b)
+	// Points on the constraint split it into two constraints
+	if split >= 0 {
+		if err := d.insertConstraint(a, split); err != nil {
+			return err
+		}
+		return d.insertConstraint(split, b)
+	}
+	for _, e := range queue {
+		if d.fixed[sortedPair(e[0], e[1])] > 0 {
+			return fmt.Errorf("%w: %v-%v and %v-%v", ErrConstraintCross, d.coords[a], d.coords[b], d.coords[e[0]], d.coords[e[1]])
+		}
+	}
+
+	for limit := 100*len(queue) + 1000; len(queue) > 0; limit-- {
+		if limit == 0 {
+			return fmt.Errorf("%w: %v-%v", ErrConstraintNotInserted, d.coords[a], d.coords[b])
+		}
+		u, v := queue[0][0], queue[0][1]
+		queue = queue[1:]
+		p, q, ok := d.quad(u, v)
+		if !ok {
+			continue
+		}
+		// Non-convex quadrilaterals cannot be flipped yet
+		if !d.isCross(p, q, u, v) {
+			queue = append(queue, [2]int{u, v})
+			continue
+		}
+		d.flip(u, v)
+		if d.isCross(a, b, p, q) {
+			queue = append(queue, [2]int{p, q})
+		}
+	}
+	d.fixed[key]++
+	return nil
+}
+
+// crossedEdges walks the triangles along a->b and returns the edges properly crossed by the segment,
+// or the point of the segment closest to a lying on it, which splits the constraint.
+// Crossed edges are ordered from a to b, each as [right, left] of a->b.
+func (d *cdt) crossedEdges(a, b int) ([][2]int, int) {
+	pa, pb := d.points[a], d.points[b]
+	// Points on the segment are past a and ahead along a->b
+	onSegment := func(k int) bool {
+		pk := d.points[k]
+		return d.orient(a, b, pk) == 0 && (pk[0]-pa[0])*(pb[0]-pa[0])+(pk[1]-pa[1])*(pb[1]-pa[1]) > 0
+	}
+
+	// Turn around a to the triangle a, r, l whose wedge holds the segment
+	ti := d.locate(pa)
+	var r, l int
+	for step := 0; ; step++ {
+		if ti < 0 || step > len(d.tris) {
+			return nil, -1
+		}
+		t := d.tris[ti]
+		k := slices.Index(t.v[:], a)
+		if k < 0 {
+			return nil, -1
+		}
+		r, l = t.v[(k+1)%3], t.v[(k+2)%3]
+		if onSegment(r) {
+			return nil, r
+		}
+		if d.orient(a, r, pb) > 0 && d.orient(a, l, pb) < 0 {
+			break
+		}
+		next, exist := d.edges[[2]int{a, l}]
+		if !exist {
+			return nil, -1
+		}
+		ti = next
+	}
+
+	// Cross the triangles until the one holding b, moving the crossed edge with each opposite point
+	var crossed [][2]int
+	for range d.tris {
+		crossed = append(crossed, [2]int{r, l})
+		ni, exist := d.edges[[2]int{l, r}]
+		if !exist {
+			return crossed, -1
+		}
+		w := d.opposite(ni, l, r)
+		switch o := d.orient(a, b, d.points[w]); {
+		case w == b:
+			return crossed, -1
+		case o == 0:
+			return nil, w
+		case o > 0:
+			l = w
+		default:
+			r = w
+		}
+	}
+	return crossed, -1
+}
+
+// classify marks the triangles inside the rings with the even-odd rule
+// Triangles touching the super triangle are outside, and crossing a constraint edge held by n rings
+// changes sides n times, so the sides spread from the outside across the whole triangulation.
+func (d *cdt) classify() []bool {
+	depth := make([]int, len(d.tris))
+	var stack []int
+	for ti, t := range d.tris {
+		depth[ti] = -1
+		if t.alive && !d.isInput(t) {
+			depth[ti] = 0
+			stack = append(stack, ti)
+		}
+	}
+	for len(stack) > 0 {
+		ti := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		t := d.tris[ti]
+		for k := 0; k < 3; k++ {
+			a, b := t.v[k], t.v[(k+1)%3]
+			ni, exist := d.edges[[2]int{b, a}]
+			if !exist || depth[ni] >= 0 {
+				continue
+			}
+			depth[ni] = depth[ti] + d.fixed[sortedPair(a, b)]
+			stack = append(stack, ni)
+		}
+	}
+
+	inside := make([]bool, len(d.tris))
+	for ti, t := range d.tris {
+		inside[ti] = t.alive && d.isInput(t) && depth[ti]%2 == 1
+	}
+	return inside
+}
+
+// isInput checks if the triangle is made of input points only, without super triangle points
+func (d *cdt) isInput(t cdtTriangle) bool {
+	return t.v[0] < len(d.coords) && t.v[1] < len(d.coords) && t.v[2] < len(d.coords)
+}
+
+// legalize flips non-constraint edges until every edge is locally Delaunay
+func (d *cdt) legalize() {
+	for limit := 10 * len(d.tris); limit > 0; limit-- {
+		flipped := false
+		for ti := range d.tris {
+			t := d.tris[ti]
+			if !t.alive {
+				continue
+			}
+			for k := 0; k < 3; k++ {
+				u, v := t.v[k], t.v[(k+1)%3]
+				if d.fixed[sortedPair(u, v)] > 0 {
+					continue
+				}
+				p, q, ok := d.quad(u, v)
+				if !ok || d.inCircle(ti, d.points[q]) <= 0 || !d.isCross(p, q, u, v) {
+					continue
+				}
+				d.flip(u, v)
+				flipped = true
+				break
+			}
+		}
+		if !flipped {
+			return
+		}
+	}
+}
+
+// quad returns the points opposite to the edge u->v and v->u
+func (d *cdt) quad(u, v int) (int, int, bool) {
+	t1, ok1 := d.edges[[2]int{u, v}]
+	t2, ok2 := d.edges[[2]int{v, u}]
+	if !ok1 || !ok2 {
+		return 0, 0, false
+	}
+	return d.opposite(t1, u, v), d.opposite(t2, u, v), true
+}
+
+// flip replaces the edge u-v shared by two triangles with the other diagonal
+func (d *cdt) flip(u, v int) {
+	t1 := d.edges[[2]int{u, v}]
+	t2 := d.edges[[2]int{v, u}]
+	p := d.opposite(t1, u, v)
+	q := d.opposite(t2, u, v)
+	d.removeTriangle(t1)
+	d.removeTriangle(t2)
+	// The quadrilateral is u, q, v, p in counter-clockwise order
+	d.setTriangle(t1, u, q, p)
+	d.setTriangle(t2, q, v, p)
+}
+
+// opposite returns the point of a triangle which is not u or v
+func (d *cdt) opposite(ti, u, v int) int {
+	for _, k := range d.tris[ti].v {
+		if k != u && k != v {
+			return k
+		}
+	}
+	return -1
+}
+
+// addTriangle appends a counter-clockwise triangle
+func (d *cdt) addTriangle(a, b, c int) {
+	d.tris = append(d.tris, cdtTriangle{})
+	d.setTriangle(len(d.tris)-1, a, b, c)
+}
+
+// setTriangle stores a counter-clockwise triangle at the given slot
+func (d *cdt) setTriangle(ti, a, b, c int) {
+	d.tris[ti] = cdtTriangle{v: [3]int{a, b, c}, alive: true}
+	d.last = ti
+	d.edges[[2]int{a, b}] = ti
+	d.edges[[2]int{b, c}] = ti
+	d.edges[[2]int{c, a}] = ti
+}
+
+// removeTriangle marks a triangle dead and removes its edges
+func (d *cdt) removeTriangle(ti int) {
+	t := &d.tris[ti]
+	for k := 0; k < 3; k++ {
+		e := [2]int{t.v[k], t.v[(k+1)%3]}
+		if d.edges[e] == ti {
+			delete(d.edges, e)
+		}
+	}
+	t.alive = false
+}
+
+// orient returns twice the signed area of a, b, p, positive when p is on the left of a->b
+func (d *cdt) orient(a, b int, p [2]float64) float64 {
+	pa, pb := d.points[a], d.points[b]
+	return (pb[0]-pa[0])*(p[1]-pa[1]) - (pb[1]-pa[1])*(p[0]-pa[0])
+}
+
+// isCross checks if segments a-b and u-v properly cross each other
+func (d *cdt) isCross(a, b, u, v int) bool {
+	if a == u || a == v || b == u || b == v {
+		return false
+	}
+	o1 := d.orient(a, b, d.points[u])
+	o2 := d.orient(a, b, d.points[v])
+	o3 := d.orient(u, v, d.points[a])
+	o4 := d.orient(u, v, d.points[b])
+	return ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) && ((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0))
+}
+
+// inCircle returns a positive value when p is inside the circumcircle of the triangle
+func (d *cdt) inCircle(ti int, p [2]float64) float64 {
+	t := d.tris[ti]
+	a, b, c := d.points[t.v[0]], d.points[t.v[1]], d.points[t.v[2]]
+	adx, adz := a[0]-p[0], a[1]-p[1]
+	bdx, bdz := b[0]-p[0], b[1]-p[1]
+	cdx, cdz := c[0]-p[0], c[1]-p[1]
+	return (adx*adx+adz*adz)*(bdx*cdz-cdx*bdz) -
+		(bdx*bdx+bdz*bdz)*(adx*cdz-cdx*adz) +
+		(cdx*cdx+cdz*cdz)*(adx*bdz-bdx*adz)
+}
+
+// sortedPair returns the two indices in ascending order
+func sortedPair(a, b int) [2]int {
+	if a > b {
+		return [2]int{b, a}
+	}
+	return [2]int{a, b}
+}
@@ -0,0 +1,74 @@
+package geo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// squareRing returns the corners of a square, counter-clockwise or clockwise
+func squareRing(x, z, size int32, clockwise bool) []Coord {
+	ring := []Coord{{X: x, Z: z}, {X: x + size, Z: z}, {X: x + size, Z: z + size}, {X: x, Z: z + size}}
+	if clockwise {
+		slices.Reverse(ring)
+	}
+	return ring
+}
+
+// checkTriangulation checks that every triangle is clockwise, that the triangles cover twice area
+// and that no triangle centroid lies inside the holes
+func checkTriangulation(t *testing.T, triangles []*Triangle, area2 int64, holes ...Rectangle) {
+	t.Helper()
+	var sum int64
+	for _, tri := range triangles {
+		a, b, c := tri.Vertices[0].Coord, tri.Vertices[1].Coord, tri.Vertices[2].Coord
+		cr := cross(a, b, c)
+		if cr >= 0 {
+			t.Errorf("triangle %d %v is not clockwise", tri.Index, tri.GetCoords())
+		}
+		sum -= cr
+		// Three times the centroid, compared against the open hole interior
+		x, z := a.X+b.X+c.X, a.Z+b.Z+c.Z
+		for _, hole := range holes {
+			if x > 3*hole.X && x < 3*(hole.X+hole.Width) && z > 3*hole.Z && z < 3*(hole.Z+hole.Height) {
+				t.Errorf("triangle %d %v lies inside the hole", tri.Index, tri.GetCoords())
+			}
+		}
+	}
+	if sum != area2 {
+		t.Errorf("triangles cover twice area %d, want %d", sum, area2)
+	}
+}
+
+func TestConstrainedDelaunay(t *testing.T) {
+	hole := NewRectangle(40, 40, 20, 20)
+	for _, clockwise := range []bool{false, true} {
+		outer := squareRing(0, 0, 100, clockwise)
+		holes := [][]Coord{squareRing(40, 40, 20, !clockwise)}
+		triangles, err := ConstrainedDelaunay(outer, holes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTriangulation(t, triangles, 2*(100*100-20*20), hole)
+
+		again, err := ConstrainedDelaunay(outer, holes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.EqualFunc(triangles, again, func(a, b *Triangle) bool { return slices.Equal(a.Vertices, b.Vertices) }) {
+			t.Errorf("ConstrainedDelaunay() is not deterministic")
+		}
+	}
+}
+
+func TestConstrainedDelaunayInvalidRing(t *testing.T) {
+	ring := []Coord{{X: 0, Z: 0}, {X: 10, Z: 0}, {X: 0, Z: 0}}
+	if _, err := ConstrainedDelaunay(ring, nil); !errors.Is(err, ErrInvalidRing) {
+		t.Errorf("ConstrainedDelaunay() error = %v, want %v", err, ErrInvalidRing)
+	}
+	outer := squareRing(0, 0, 100, false)
+	crossing := []Coord{{X: -10, Z: 50}, {X: 110, Z: 50}, {X: 50, Z: 60}}
+	if _, err := ConstrainedDelaunay(outer, [][]Coord{crossing}); !errors.Is(err, ErrConstraintCross) {
+		t.Errorf("ConstrainedDelaunay() with crossing rings error = %v, want %v", err, ErrConstraintCross)
+	}
+}
@@ -36,6 +36,25 @@ func (c *Circle) ToRect() (minX, minZ, maxX, maxZ int32) {
 	return
 }
 
+// IsCoordInside checks if point is inside the circle, points on the boundary are inside
+func (c *Circle) IsCoordInside(p Coord) bool {
+	r := float64(c.Radius)
+	return CalDstCoordToCoordWithoutSqrt(c.Center, p) <= r*r
+}
+
+// Support returns the farthest point of the circle in the direction
+func (c *Circle) Support(dir Vector) Coord {
+	length := dir.Length()
+	if length == 0 {
+		return c.Center
+	}
+	ratio := float64(c.Radius) / length
+	return Coord{
+		X: c.Center.X + int32(math.Round(float64(dir.X)*ratio)),
+		Z: c.Center.Z + int32(math.Round(float64(dir.Z)*ratio)),
+	}
+}
+
 // GetIntersectCoord calculates the intersection point between circle and line from external point to center
 // p: external point outside the circle
 // Returns the intersection point on the circle
@@ -102,6 +121,12 @@ func (c *Circle) GetLineCross(s *Segment) (Coord, bool) {
 	return *coord1, true
 }
 
+// IsInterCircle checks if two circles intersect, touching circles intersect
+func (c *Circle) IsInterCircle(o *Circle) bool {
+	radius := float64(c.Radius) + float64(o.Radius)
+	return CalDstCoordToCoordWithoutSqrt(c.Center, o.Center) <= radius*radius
+}
+
 // IsInterPolygon checks if circle intersects with polygon
 // Returns true if intersected, false if separated
 // Intersection cases: circle inside polygon, polygon inside circle, partial intersection
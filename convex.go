@@ -1,23 +1,34 @@
 package geo
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"math"
+	"slices"
 )
 
+// ErrInvalidConvex is returned when a convex polygon is not correctly formed
+var ErrInvalidConvex = errors.New("geo: invalid convex")
+
 // Convex represents a convex polygon
 type Convex struct {
 	Index          int32       // Unique identifier for the convex polygon
+	AreaType       uint8       // Area type shared by the merged triangles
+	Flags          uint16      // Ability flags shared by the merged triangles
 	Vertices       []Vertice   // Vertices of the polygon (triangles contain three vertices)
 	MergeTriangles []*Triangle // Triangles that compose this convex polygon
 	EdgeIDs        []int32     // Edge identifiers
+	EdgeKeys       []EdgeKey   // Collision-free 64-bit edge keys
 	WtCoord        Coord       // Weight coordinate (center of mass)
 }
 
 // NewConvex converts a triangle to a convex polygon
 func NewConvex(t *Triangle, id int32) *Convex {
 	return &Convex{
-		Index: id,
+		Index:    id,
+		AreaType: t.AreaType,
+		Flags:    t.Flags,
 		Vertices: []Vertice{
 			t.Vertices[0],
 			t.Vertices[1],
@@ -25,6 +36,7 @@ func NewConvex(t *Triangle, id int32) *Convex {
 		},
 		MergeTriangles: []*Triangle{t},
 		EdgeIDs:        t.EdgeIDs,
+		EdgeKeys:       t.EdgeKeys,
 	}
 }
 
@@ -33,6 +45,8 @@ func NewConvex(t *Triangle, id int32) *Convex {
 func (c *Convex) ToRect() (minX, minZ, maxX, maxZ int32) {
 	minX = int32(math.MaxInt32)
 	minZ = int32(math.MaxInt32)
+	maxX = int32(math.MinInt32)
+	maxZ = int32(math.MinInt32)
 	for _, v := range c.Vertices {
 		minX = min(v.Coord.X, minX)
 		minZ = min(v.Coord.Z, minZ)
@@ -42,6 +56,12 @@ func (c *Convex) ToRect() (minX, minZ, maxX, maxZ int32) {
 	return minX, minZ, maxX, maxZ
 }
 
+// GetLocationToBorder returns the positional relationship between convex polygon and given border
+func (c *Convex) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := c.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
 // MergeTriangle merges new triangle to form a new convex polygon
 // p1, p2: shared vertices between triangles
 // p3: additional vertices from the new triangle
@@ -101,15 +121,34 @@ func (c *Convex) MergeTriangle(p1, p2 Vertice, p3 []Vertice) bool {
 
 // GetVectors returns vector array of convex polygon in counter-clockwise order
 // Adjacent edges of convex polygon have positive cross product in counter-clockwise order
+// The vertices are left in their stored order, a reversed copy is used when they are clockwise.
 func (c *Convex) GetVectors() []Vector {
-	c.CounterClockWiseSort()
-	vecs := make([]Vector, 0, len(c.Vertices))
-	for _, v := range c.Vertices {
+	vertices := ccwVertices(c.Vertices)
+	vecs := make([]Vector, 0, len(vertices))
+	for _, v := range vertices {
 		vecs = append(vecs, NewVectorByCoord(v.Coord))
 	}
 	return vecs
 }
 
+// GetCoords returns vertex coordinates of convex polygon in counter-clockwise order
+func (c *Convex) GetCoords() []Coord {
+	vecs := c.GetVectors()
+	coords := make([]Coord, len(vecs))
+	for i, v := range vecs {
+		coords[i] = v.ToCoord(Coord{})
+	}
+	return coords
+}
+
+// convexPolygon marks the convex polygon as a ConvexPolygon
+func (c *Convex) convexPolygon() {}
+
+// Support returns the convex polygon vertex farthest in the direction
+func (c *Convex) Support(dir Vector) Coord {
+	return supportCoords(verticeCoords(c.Vertices), dir)
+}
+
 // TriangleHasCoord returns the triangle index that contains the given point
 func (c *Convex) TriangleHasCoord(p Coord) int32 {
 	for _, t := range c.MergeTriangles {
@@ -125,7 +164,7 @@ func (c *Convex) IsCoordInside1(p Coord) bool {
 	if len(c.MergeTriangles) == 1 {
 		return c.MergeTriangles[0].IsCoordInside(p)
 	}
-	c.CounterClockWiseSort()
+	// The crossing parity does not depend on the winding, the vertices are used as stored
 	x := p.X
 	y := p.Z
 	sz := len(c.Vertices)
@@ -213,15 +252,20 @@ func (c *Convex) IsCoordInside2(p Coord) bool {
 }
 
 // IsCoordInside checks if point is inside convex polygon
+// The point is inside when it is on the same side of every edge, whatever the winding of the vertices.
+// Points on the edges and vertices are inside, like Triangle.IsCoordInside.
 func (c *Convex) IsCoordInside(p Coord) bool {
 	numOfVertice := len(c.Vertices)
-	vec1 := NewVector(c.Vertices[0].Coord, c.Vertices[1].Coord)
-	vec2 := NewVector(c.Vertices[0].Coord, p)
-	isCounterClockwise := vec1.Cross(&vec2) > 0
-	for i := 1; i < numOfVertice; i++ {
+	hasNeg := false
+	hasPos := false
+	for i := 0; i < numOfVertice; i++ {
 		vec1 := NewVector(c.Vertices[i].Coord, c.Vertices[(i+1)%numOfVertice].Coord)
 		vec2 := NewVector(c.Vertices[i].Coord, p)
-		if (vec1.Cross(&vec2) > 0) != isCounterClockwise {
+		// Collinear results are compatible with either side, so points on edges are inside
+		cp := vec1.Cross(&vec2)
+		hasNeg = hasNeg || cp < 0
+		hasPos = hasPos || cp > 0
+		if hasNeg && hasPos {
 			return false
 		}
 	}
@@ -230,13 +274,22 @@ func (c *Convex) IsCoordInside(p Coord) bool {
 
 // CheckConvex validates if this convex polygon is correctly formed (for testing)
 func (c *Convex) CheckConvex() bool {
+	if err := c.Validate(); err != nil {
+		log.Printf("[ERROR] %v\n", err)
+		return false
+	}
+	return true
+}
+
+// Validate checks if this convex polygon is correctly formed
+// The vertices must form a convex polygon and match the vertices of the merged triangles
+func (c *Convex) Validate() error {
 	vers := make(map[int32]bool)
 	for _, ver := range c.Vertices {
 		vers[ver.Index] = false
 	}
 	if !IsConvex(c.Vertices) {
-		log.Printf("[ERROR] convex error not a convex polygon\n")
-		return false
+		return fmt.Errorf("%w: convex %d is not a convex polygon", ErrInvalidConvex, c.Index)
 	}
 	count1 := len(vers)
 	for _, triangle := range c.MergeTriangles {
@@ -247,16 +300,15 @@ func (c *Convex) CheckConvex() bool {
 	count2 := len(vers)
 	for key, value := range vers {
 		if !value {
-			log.Printf("[ERROR] convex error has not exist value %d\n", key)
-			return false
+			return fmt.Errorf("%w: convex %d has vertex %d not in merged triangles", ErrInvalidConvex, c.Index, key)
 		}
 	}
 
 	if count1 != count2 {
-		log.Printf("[ERROR] convex error has not merged\nvertice convex vertices count: %d merge vertices count: %d\n", count1, count2)
-		return false
+		return fmt.Errorf("%w: convex %d has not merged, convex vertices count: %d merge vertices count: %d",
+			ErrInvalidConvex, c.Index, count1, count2)
 	}
-	return true
+	return nil
 }
 
 // CounterClockWiseSort sorts vertices in counter-clockwise order
@@ -269,6 +321,21 @@ func (c *Convex) CounterClockWiseSort() {
 	}
 }
 
+// ccwVertices returns the vertices in counter-clockwise order
+func ccwVertices(vertices []Vertice) []Vertice {
+	var area int64
+	for i, v := range vertices {
+		q := vertices[(i+1)%len(vertices)].Coord
+		area += int64(v.Coord.X)*int64(q.Z) - int64(q.X)*int64(v.Coord.Z)
+	}
+	if area >= 0 {
+		return vertices
+	}
+	reversed := slices.Clone(vertices)
+	slices.Reverse(reversed)
+	return reversed
+}
+
 // GetIndex returns the polygon index
 func (c *Convex) GetIndex() int32 {
 	return c.Index
@@ -352,6 +419,11 @@ func (c *Convex) GetEdgeIDs() []int32 {
 	return c.EdgeIDs
 }
 
+// GetEdgeKeys returns the list of 64-bit edge keys
+func (c *Convex) GetEdgeKeys() []EdgeKey {
+	return c.EdgeKeys
+}
+
 // GetEdgeMidCoords returns the midpoints of all edges
 func (c *Convex) GetEdgeMidCoords() []Coord {
 	nums := len(c.Vertices)
@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConvexIsCoordInside(t *testing.T) {
+	// Hexagon with a vertical edge from (100,0) to (100,100)
+	counterClockwise := []Vertice{
+		{Index: 0, Coord: Coord{X: 0, Z: 0}},
+		{Index: 1, Coord: Coord{X: 100, Z: 0}},
+		{Index: 2, Coord: Coord{X: 100, Z: 100}},
+		{Index: 3, Coord: Coord{X: 50, Z: 150}},
+		{Index: 4, Coord: Coord{X: 0, Z: 100}},
+		{Index: 5, Coord: Coord{X: -20, Z: 50}},
+	}
+	clockwise := slices.Clone(counterClockwise)
+	slices.Reverse(clockwise)
+	tests := []struct {
+		name string
+		p    Coord
+		want bool
+	}{
+		{name: "inside", p: Coord{X: 50, Z: 50}, want: true},
+		{name: "on an edge", p: Coord{X: 100, Z: 40}, want: true},
+		{name: "on a slanted edge", p: Coord{X: 75, Z: 125}, want: true},
+		{name: "on a vertex", p: Coord{X: 50, Z: 150}, want: true},
+		{name: "outside", p: Coord{X: 101, Z: 40}, want: false},
+		{name: "on the edge line beyond a vertex", p: Coord{X: 100, Z: 120}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, vertices := range [][]Vertice{counterClockwise, clockwise} {
+				c := &Convex{Vertices: vertices}
+				if got := c.IsCoordInside(tt.p); got != tt.want {
+					t.Errorf("IsCoordInside(%v) with %v = %v, want %v", tt.p, c.GetCoords(), got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestConvexGetCoords(t *testing.T) {
+	vertices := []Vertice{
+		{Index: 0, Coord: Coord{X: 0, Z: 0}},
+		{Index: 1, Coord: Coord{X: 0, Z: 100}},
+		{Index: 2, Coord: Coord{X: 100, Z: 100}},
+		{Index: 3, Coord: Coord{X: 100, Z: 0}},
+	}
+	c := &Convex{Vertices: slices.Clone(vertices)}
+	coords := c.GetCoords()
+	var area int64
+	for i := range coords {
+		a, b := coords[i], coords[(i+1)%len(coords)]
+		area += int64(a.X)*int64(b.Z) - int64(b.X)*int64(a.Z)
+	}
+	if area <= 0 {
+		t.Errorf("GetCoords() = %v, want counter-clockwise coordinates", coords)
+	}
+	if !slices.Equal(c.Vertices, vertices) {
+		t.Errorf("GetCoords() reordered the vertices to %v", c.Vertices)
+	}
+}
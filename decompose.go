@@ -0,0 +1,151 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ErrDuplicateTriangleIndex is returned when several triangles share the same index
+var ErrDuplicateTriangleIndex = errors.New("geo: duplicate triangle index")
+
+// DecomposeConvex merges a triangulated mesh into convex polygons with the Hertel-Mehlhorn algorithm
+// Adjacency edges are visited from the longest to the shortest, and the two polygons
+// on both sides are merged whenever the result stays convex.
+// Triangles are expected to be sorted clockwise as produced by MeshBuilder,
+// triangles of different area types or flags are never merged.
+// Returns convex polygons indexed from 0 with MergeTriangles, EdgeIDs and EdgeKeys holding
+// the merged triangles and the boundary edges, and the validation errors of invalid polygons.
+// Triangle indices identify the triangles of the edges, ErrDuplicateTriangleIndex is returned
+// without polygons when they are not unique.
+func DecomposeConvex(triangles []*Triangle, edges []Edge) ([]*Convex, error) {
+	owners := make(map[int32]*Convex, len(triangles))
+	for _, t := range triangles {
+		if _, exist := owners[t.Index]; exist {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateTriangleIndex, t.Index)
+		}
+		c := NewConvex(t, t.Index)
+		c.EdgeIDs = slices.Clone(c.EdgeIDs)
+		c.EdgeKeys = slices.Clone(c.EdgeKeys)
+		owners[t.Index] = c
+	}
+
+	// Longer diagonals first, removing them tends to leave fewer polygons
+	diagonals := make([]*Edge, 0, len(edges))
+	for i := range edges {
+		if edges[i].IsAdjacency && len(edges[i].AdjacenctTriangles) == 2 {
+			diagonals = append(diagonals, &edges[i])
+		}
+	}
+	sort.SliceStable(diagonals, func(i, j int) bool {
+		a := CalDstCoordToCoordWithoutSqrt(diagonals[i].Vertices[0].Coord, diagonals[i].Vertices[1].Coord)
+		b := CalDstCoordToCoordWithoutSqrt(diagonals[j].Vertices[0].Coord, diagonals[j].Vertices[1].Coord)
+		return a > b
+	})
+
+	for merged := true; merged; {
+		merged = false
+		for _, e := range diagonals {
+			c1 := owners[e.AdjacenctTriangles[0].Index]
+			c2 := owners[e.AdjacenctTriangles[1].Index]
+			if c1 == nil || c2 == nil || c1 == c2 || c1.AreaType != c2.AreaType || c1.Flags != c2.Flags {
+				continue
+			}
+			if !mergeConvex(c1, c2, e) {
+				continue
+			}
+			for _, t := range c2.MergeTriangles {
+				owners[t.Index] = c1
+			}
+			merged = true
+		}
+	}
+
+	var errs []error
+	convexes := make([]*Convex, 0, len(triangles))
+	added := make(map[*Convex]bool, len(triangles))
+	for _, t := range triangles {
+		c := owners[t.Index]
+		if added[c] {
+			continue
+		}
+		added[c] = true
+		c.Index = int32(len(convexes))
+		c.WtCoord = c.GetCenterCoord()
+		convexes = append(convexes, c)
+
+		if err := c.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := validateConvexEdges(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return convexes, errors.Join(errs...)
+}
+
+// mergeConvex merges c2 into c1 across the shared edge if the result is convex
+func mergeConvex(c1, c2 *Convex, e *Edge) bool {
+	// Neighbor points start with the shared edge in the order of c2
+	points := c1.GetNeighborPoints(c2)
+	if len(points) < 3 {
+		return false
+	}
+	p1, p2 := points[0], points[1]
+	key := GenEdgeKey64(p1.Index, p2.Index)
+	if key != e.GenKey64() {
+		return false
+	}
+
+	// With the same winding the shared edge is p2->p1 in c1, so c2 is inserted between them
+	candidate := make([]Vertice, 0, len(c1.Vertices)+len(points)-2)
+	for i, v := range c1.Vertices {
+		candidate = append(candidate, v)
+		if v.Index == p2.Index && c1.Vertices[(i+1)%len(c1.Vertices)].Index == p1.Index {
+			candidate = append(candidate, points[2:]...)
+		}
+	}
+	if len(candidate) != len(c1.Vertices)+len(points)-2 || !IsConvex(candidate) {
+		return false
+	}
+	c1.Vertices = candidate
+	c1.MergeTriangles = append(c1.MergeTriangles, c2.MergeTriangles...)
+
+	// EdgeIDs may collide, so they are dropped at the positions of the shared edge keys
+	// and only kept unfiltered when they do not line up with EdgeKeys
+	ids := append(c1.EdgeIDs, c2.EdgeIDs...)
+	keys := append(c1.EdgeKeys, c2.EdgeKeys...)
+	if len(ids) == len(keys) {
+		c1.EdgeIDs = ids[:0]
+		for i, k := range keys {
+			if k != key {
+				c1.EdgeIDs = append(c1.EdgeIDs, ids[i])
+			}
+		}
+	} else {
+		c1.EdgeIDs = ids
+	}
+	c1.EdgeKeys = slices.DeleteFunc(keys, func(k EdgeKey) bool { return k == key })
+	return true
+}
+
+// validateConvexEdges checks that the edge keys of a convex polygon are its boundary edges
+func validateConvexEdges(c *Convex) error {
+	if len(c.EdgeKeys) == 0 {
+		return nil
+	}
+	if len(c.EdgeKeys) != len(c.Vertices) {
+		return fmt.Errorf("%w: convex %d has %d edge keys for %d vertices",
+			ErrInvalidConvex, c.Index, len(c.EdgeKeys), len(c.Vertices))
+	}
+	for i, v := range c.Vertices {
+		next := c.Vertices[(i+1)%len(c.Vertices)]
+		if !slices.Contains(c.EdgeKeys, GenEdgeKey64(v.Index, next.Index)) {
+			return fmt.Errorf("%w: convex %d has no key for boundary edge %d-%d",
+				ErrInvalidConvex, c.Index, v.Index, next.Index)
+		}
+	}
+	return nil
+}
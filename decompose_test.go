@@ -0,0 +1,74 @@
+package geo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// newFanTriangles returns the clockwise fan triangles of a counter-clockwise convex polygon
+func newFanTriangles(vertices ...Vertice) []*Triangle {
+	var triangles []*Triangle
+	for i := 1; i+1 < len(vertices); i++ {
+		triangles = append(triangles, &Triangle{
+			Index:    int32(len(triangles)),
+			Vertices: []Vertice{vertices[0], vertices[i+1], vertices[i]},
+		})
+	}
+	return triangles
+}
+
+func TestDecomposeConvex(t *testing.T) {
+	// The boundary edge 1-10001 and the diagonal 0-20001 share the 32-bit key 20001,
+	// the longer diagonal 0-1 is removed first so the boundary edge is already merged
+	triangles := newFanTriangles(
+		Vertice{Index: 0, Coord: Coord{X: 0, Z: 0}},
+		Vertice{Index: 2, Coord: Coord{X: 100, Z: 0}},
+		Vertice{Index: 20001, Coord: Coord{X: 150, Z: 80}},
+		Vertice{Index: 1, Coord: Coord{X: 80, Z: 170}},
+		Vertice{Index: 10001, Coord: Coord{X: 0, Z: 100}},
+	)
+	edges, err := BuildEdges(triangles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	convexes, err := DecomposeConvex(triangles, edges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(convexes) != 1 {
+		t.Fatalf("DecomposeConvex() returned %d polygons, want 1", len(convexes))
+	}
+	c := convexes[0]
+	if len(c.Vertices) != 5 || len(c.MergeTriangles) != 3 {
+		t.Errorf("DecomposeConvex() = %d vertices and %d triangles, want 5 and 3", len(c.Vertices), len(c.MergeTriangles))
+	}
+	wantKeys := []EdgeKey{
+		GenEdgeKey64(0, 2), GenEdgeKey64(2, 20001), GenEdgeKey64(1, 20001), GenEdgeKey64(1, 10001), GenEdgeKey64(0, 10001),
+	}
+	keys := slices.Sorted(slices.Values(c.EdgeKeys))
+	slices.Sort(wantKeys)
+	if !slices.Equal(keys, wantKeys) {
+		t.Errorf("EdgeKeys = %v, want %v", keys, wantKeys)
+	}
+	if !slices.Contains(c.EdgeIDs, GenEdgeKey(1, 10001)) || len(c.EdgeIDs) != len(c.EdgeKeys) {
+		t.Errorf("EdgeIDs = %v, want the IDs of the 5 boundary edges", c.EdgeIDs)
+	}
+}
+
+func TestDecomposeConvexDuplicateIndex(t *testing.T) {
+	triangles := newFanTriangles(
+		Vertice{Index: 0, Coord: Coord{X: 0, Z: 0}},
+		Vertice{Index: 1, Coord: Coord{X: 100, Z: 0}},
+		Vertice{Index: 2, Coord: Coord{X: 100, Z: 100}},
+		Vertice{Index: 3, Coord: Coord{X: 0, Z: 100}},
+	)
+	triangles[1].Index = triangles[0].Index
+	edges, err := BuildEdges(triangles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecomposeConvex(triangles, edges); !errors.Is(err, ErrDuplicateTriangleIndex) {
+		t.Errorf("DecomposeConvex() error = %v, want %v", err, ErrDuplicateTriangleIndex)
+	}
+}
@@ -0,0 +1,272 @@
+package geo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EarClipping triangulates a simple polygon with holes by ear clipping
+// Holes are bridged into the outer ring before clipping, which is faster than
+// ConstrainedDelaunay for small polygons but produces thinner triangles.
+// outer: boundary ring of the polygon, holes: rings inside the boundary, in either winding order
+// Returns triangles sorted clockwise that can be passed to NewConvex,
+// Vertice.Index numbers the distinct ring points in order of appearance starting from the outer ring.
+func EarClipping(outer []Coord, holes [][]Coord) ([]*Triangle, error) {
+	e := &earClipper{lookup: make(map[Coord]int32)}
+
+	ring, err := e.addRing(outer, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var holeRings [][]int32
+	for i, hole := range holes {
+		r, err := e.addRing(hole, i+1, false)
+		if err != nil {
+			return nil, err
+		}
+		holeRings = append(holeRings, r)
+	}
+
+	// Holes with the rightmost vertex are bridged first
+	sort.SliceStable(holeRings, func(i, j int) bool {
+		return e.coords[e.rightmost(holeRings[i])].X > e.coords[e.rightmost(holeRings[j])].X
+	})
+	for _, hole := range holeRings {
+		if ring, err = e.bridge(ring, hole); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.clip(ring)
+}
+
+// earClipper holds the points of an ear clipping triangulation
+type earClipper struct {
+	coords []Coord         // Distinct points
+	lookup map[Coord]int32 // Coordinate to point index
+}
+
+// addRing adds a ring and returns its point indices, counter-clockwise for the outer ring
+// and clockwise for holes
+func (e *earClipper) addRing(coords []Coord, n int, counterClockwise bool) ([]int32, error) {
+	ring := make([]int32, 0, len(coords))
+	for _, p := range coords {
+		index, exist := e.lookup[p]
+		if !exist {
+			index = int32(len(e.coords))
+			e.lookup[p] = index
+			e.coords = append(e.coords, p)
+		}
+		// Repeated points are dropped
+		if len(ring) > 0 && (ring[len(ring)-1] == index || ring[0] == index) {
+			continue
+		}
+		ring = append(ring, index)
+	}
+	if len(ring) < 3 {
+		return nil, fmt.Errorf("%w: ring %d", ErrInvalidRing, n)
+	}
+
+	var area int64
+	for i := range ring {
+		a := e.coords[ring[i]]
+		b := e.coords[ring[(i+1)%len(ring)]]
+		area += int64(a.X)*int64(b.Z) - int64(b.X)*int64(a.Z)
+	}
+	if area == 0 {
+		return nil, fmt.Errorf("%w: ring %d has no area", ErrInvalidRing, n)
+	}
+	if (area > 0) != counterClockwise {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+	return ring, nil
+}
+
+// rightmost returns the index of the ring point with the largest X
+func (e *earClipper) rightmost(ring []int32) int32 {
+	best := ring[0]
+	for _, index := range ring {
+		if c, b := e.coords[index], e.coords[best]; c.X > b.X || (c.X == b.X && c.Z < b.Z) {
+			best = index
+		}
+	}
+	return best
+}
+
+// bridge connects a hole to the outer ring with a pair of coincident edges
+// Reference: https://www.geometrictools.com/Documentation/TriangulationByEarClipping.pdf
+func (e *earClipper) bridge(ring, hole []int32) ([]int32, error) {
+	m := e.rightmost(hole)
+	mc := e.coords[m]
+
+	// Cast a ray from M to +X and find the closest edge it hits
+	hit := -1
+	hitX := 0.
+	for i := range ring {
+		a := e.coords[ring[i]]
+		b := e.coords[ring[(i+1)%len(ring)]]
+		// Only edges going up can be hit from the inside of a counter-clockwise ring
+		if a.Z > mc.Z || b.Z < mc.Z || a.Z == b.Z {
+			continue
+		}
+		x := float64(a.X) + float64(mc.Z-a.Z)*float64(b.X-a.X)/float64(b.Z-a.Z)
+		if x < float64(mc.X) {
+			continue
+		}
+		if hit < 0 || x < hitX {
+			hit = i
+			hitX = x
+		}
+	}
+	if hit < 0 {
+		return nil, fmt.Errorf("%w: hole at %v is outside the boundary", ErrInvalidRing, mc)
+	}
+
+	// The visible candidate is the hit vertex or the edge endpoint with the larger X
+	candidate := hit
+	a, b := e.coords[ring[hit]], e.coords[ring[(hit+1)%len(ring)]]
+	if (b.Z == mc.Z && float64(b.X) == hitX) || (b.X > a.X && (a.Z != mc.Z || float64(a.X) != hitX)) {
+		candidate = (hit + 1) % len(ring)
+	}
+	p := e.coords[ring[candidate]]
+
+	// Reflex vertices inside the triangle M, I, P may hide P, the one with the smallest angle to the ray wins
+	if float64(p.X) != hitX || p.Z != mc.Z {
+		i := Coord{X: int32(hitX), Z: mc.Z}
+		bestAngle := -1.
+		for k, index := range ring {
+			c := e.coords[index]
+			if k == candidate || c == mc || !e.isReflex(ring, k) || !isInsideTriangle(mc, i, p, c) {
+				continue
+			}
+			dx, dz := float64(c.X-mc.X), float64(c.Z-mc.Z)
+			if dx <= 0 {
+				continue
+			}
+			angle := dz * dz / (dx*dx + dz*dz)
+			if bestAngle < 0 || angle < bestAngle {
+				bestAngle = angle
+				candidate = k
+			}
+		}
+	}
+
+	// Coincident points created by earlier bridges are taken where M lies inside the wedge
+	target := ring[candidate]
+	for k, index := range ring {
+		if index == target && e.isInsideWedge(ring, k, mc) {
+			candidate = k
+			break
+		}
+	}
+
+	start := 0
+	for k, index := range hole {
+		if index == m {
+			start = k
+			break
+		}
+	}
+	merged := make([]int32, 0, len(ring)+len(hole)+2)
+	merged = append(merged, ring[:candidate+1]...)
+	for k := 0; k <= len(hole); k++ {
+		merged = append(merged, hole[(start+k)%len(hole)])
+	}
+	merged = append(merged, ring[candidate])
+	merged = append(merged, ring[candidate+1:]...)
+	return merged, nil
+}
+
+// isReflex checks if the ring vertex at position k is reflex
+func (e *earClipper) isReflex(ring []int32, k int) bool {
+	prev := e.coords[ring[(k+len(ring)-1)%len(ring)]]
+	next := e.coords[ring[(k+1)%len(ring)]]
+	return cross(e.coords[ring[k]], next, prev) < 0
+}
+
+// isInsideWedge checks if the direction from ring vertex k to p points into the polygon
+func (e *earClipper) isInsideWedge(ring []int32, k int, p Coord) bool {
+	prev := e.coords[ring[(k+len(ring)-1)%len(ring)]]
+	cur := e.coords[ring[k]]
+	next := e.coords[ring[(k+1)%len(ring)]]
+	left1 := cross(cur, p, prev) >= 0
+	left2 := cross(next, p, cur) >= 0
+	if e.isReflex(ring, k) {
+		return left1 || left2
+	}
+	return left1 && left2
+}
+
+// clip removes ears from the counter-clockwise ring until it is fully triangulated
+func (e *earClipper) clip(ring []int32) ([]*Triangle, error) {
+	var triangles []*Triangle
+	start := 0
+	for len(ring) > 3 {
+		clipped := false
+		for step := range ring {
+			k := (start + step) % len(ring)
+			prev := ring[(k+len(ring)-1)%len(ring)]
+			cur := ring[k]
+			next := ring[(k+1)%len(ring)]
+			area := cross(e.coords[cur], e.coords[next], e.coords[prev])
+			if area < 0 || !e.isEar(ring, prev, cur, next) {
+				continue
+			}
+			// Collinear vertices are dropped without a triangle
+			if area > 0 {
+				triangles = append(triangles, e.newTriangle(len(triangles), prev, cur, next))
+			}
+			ring = append(ring[:k], ring[k+1:]...)
+			// Continue from the previous vertex, which may have become an ear
+			start = max(k-1, 0)
+			clipped = true
+			break
+		}
+		if !clipped {
+			return triangles, fmt.Errorf("%w: polygon is self-intersecting", ErrInvalidRing)
+		}
+	}
+	if cross(e.coords[ring[1]], e.coords[ring[2]], e.coords[ring[0]]) > 0 {
+		triangles = append(triangles, e.newTriangle(len(triangles), ring[0], ring[1], ring[2]))
+	}
+	return triangles, nil
+}
+
+// isEar checks if no other ring point is inside the triangle prev, cur, next
+func (e *earClipper) isEar(ring []int32, prev, cur, next int32) bool {
+	a, b, c := e.coords[prev], e.coords[cur], e.coords[next]
+	for _, index := range ring {
+		p := e.coords[index]
+		if p == a || p == b || p == c {
+			continue
+		}
+		if isInsideTriangle(a, b, c, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// newTriangle creates a clockwise triangle from counter-clockwise point indices
+func (e *earClipper) newTriangle(index int, a, b, c int32) *Triangle {
+	return &Triangle{
+		Index: int32(index),
+		Vertices: []Vertice{
+			{Index: a, Coord: e.coords[a]},
+			{Index: c, Coord: e.coords[c]},
+			{Index: b, Coord: e.coords[b]},
+		},
+	}
+}
+
+// isInsideTriangle checks if p is inside or on the edges of triangle a, b, c in any winding
+func isInsideTriangle(a, b, c, p Coord) bool {
+	c1 := cross(a, b, p)
+	c2 := cross(b, c, p)
+	c3 := cross(c, a, p)
+	hasNeg := c1 < 0 || c2 < 0 || c3 < 0
+	hasPos := c1 > 0 || c2 > 0 || c3 > 0
+	return !(hasNeg && hasPos)
+}
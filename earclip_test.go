@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEarClipping(t *testing.T) {
+	tests := []struct {
+		name  string
+		outer []Coord
+		holes [][]Coord
+		area2 int64
+		areas []Rectangle
+	}{
+		{
+			name:  "concave L shape",
+			outer: []Coord{{X: 0, Z: 0}, {X: 100, Z: 0}, {X: 100, Z: 40}, {X: 40, Z: 40}, {X: 40, Z: 100}, {X: 0, Z: 100}},
+			area2: 2 * (100*40 + 40*60),
+		},
+		{
+			name:  "counter-clockwise square with a hole",
+			outer: squareRing(0, 0, 100, false),
+			holes: [][]Coord{squareRing(40, 40, 20, true)},
+			area2: 2 * (100*100 - 20*20),
+			areas: []Rectangle{NewRectangle(40, 40, 20, 20)},
+		},
+		{
+			name:  "clockwise square with two holes",
+			outer: squareRing(0, 0, 100, true),
+			holes: [][]Coord{squareRing(10, 10, 20, true), squareRing(60, 50, 30, false)},
+			area2: 2 * (100*100 - 20*20 - 30*30),
+			areas: []Rectangle{NewRectangle(10, 10, 20, 20), NewRectangle(60, 50, 30, 30)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			triangles, err := EarClipping(tt.outer, tt.holes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTriangulation(t, triangles, tt.area2, tt.areas...)
+		})
+	}
+}
+
+func TestEarClippingInvalidRing(t *testing.T) {
+	tests := []struct {
+		name  string
+		outer []Coord
+		holes [][]Coord
+	}{
+		{name: "too few points", outer: []Coord{{X: 0, Z: 0}, {X: 10, Z: 0}, {X: 10, Z: 0}}},
+		{name: "collinear", outer: []Coord{{X: 0, Z: 0}, {X: 10, Z: 0}, {X: 20, Z: 0}}},
+		{name: "hole outside", outer: squareRing(0, 0, 100, false), holes: [][]Coord{squareRing(200, 200, 10, false)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EarClipping(tt.outer, tt.holes); !errors.Is(err, ErrInvalidRing) {
+				t.Errorf("EarClipping() error = %v, want %v", err, ErrInvalidRing)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package geo
 
+import "sort"
+
 // Vertice represents a vertex with unique index and coordinate
 type Vertice struct {
 	Index int32 // Unique vertex identifier
@@ -20,15 +22,122 @@ func (e *Edge) CalMidCoord() Coord {
 	return CalMidCoord(e.Vertices[0].Coord, e.Vertices[1].Coord)
 }
 
-// GenKey generates a unique key for the edge
+// hasInflects checks if inflection points narrowing the edge are set
+// A portal needs two distinct points, so the zero value and other degenerate pairs count as unset.
+func (e *Edge) hasInflects() bool {
+	return e.Inflects[0].Coord != e.Inflects[1].Coord
+}
+
+// weightCoord returns the weight coordinate of the edge, the midpoint when WtCoord is unset
+// WtCoord at the origin counts as unset unless the edge passes through the origin.
+func (e *Edge) weightCoord() Coord {
+	seg := NewSegment(e.Vertices[0].Coord, e.Vertices[1].Coord)
+	if e.WtCoord == (Coord{}) && !seg.IsCoordInside(e.WtCoord) {
+		return e.CalMidCoord()
+	}
+	return e.WtCoord
+}
+
+// GenKey generates a 32-bit key for the edge
+//
+// Deprecated: keys collide once a vertex index reaches 10000, use GenKey64.
 func (e *Edge) GenKey() int32 {
 	return GenEdgeKey(e.Vertices[0].Index, e.Vertices[1].Index)
 }
 
-// GenEdgeKey generates a unique key for an edge given two vertex indices
+// GenKey64 generates a collision-free 64-bit key for the edge
+func (e *Edge) GenKey64() EdgeKey {
+	return GenEdgeKey64(e.Vertices[0].Index, e.Vertices[1].Index)
+}
+
+// GenEdgeKey generates a 32-bit key for an edge given two vertex indices
+// Keys collide once a vertex index reaches 10000 and overflow past 214748.
+//
+// Deprecated: use GenEdgeKey64.
 func GenEdgeKey(i, j int32) int32 {
 	if i < j {
 		return 10000*i + j
 	}
 	return 10000*j + i
 }
+
+// EdgeKey represents a collision-free edge key
+// The smaller vertex index is stored in the high 32 bits and the larger one in the low 32 bits
+type EdgeKey uint64
+
+// GenEdgeKey64 generates a collision-free 64-bit key for an edge given two vertex indices
+func GenEdgeKey64(i, j int32) EdgeKey {
+	if i > j {
+		i, j = j, i
+	}
+	return EdgeKey(uint64(uint32(i))<<32 | uint64(uint32(j)))
+}
+
+// Indices returns the two vertex indices of the key, smaller index first
+func (k EdgeKey) Indices() (int32, int32) {
+	return int32(uint32(k >> 32)), int32(uint32(k))
+}
+
+// Legacy returns the 32-bit key generated by GenEdgeKey for the same edge
+func (k EdgeKey) Legacy() int32 {
+	return GenEdgeKey(k.Indices())
+}
+
+// IndexEdges maps the 64-bit key of every edge to the edge
+func IndexEdges(edges []Edge) map[EdgeKey]*Edge {
+	ret := make(map[EdgeKey]*Edge, len(edges))
+	for i := range edges {
+		ret[edges[i].GenKey64()] = &edges[i]
+	}
+	return ret
+}
+
+// EdgeKeyCollision represents different edges sharing the same 32-bit key
+type EdgeKeyCollision struct {
+	Key   int32     // Colliding 32-bit key
+	Edges []EdgeKey // 64-bit keys of the colliding edges
+}
+
+// FindEdgeKeyCollisions finds edges whose GenKey results collide
+func FindEdgeKeyCollisions(edges []Edge) []EdgeKeyCollision {
+	keys := make([]EdgeKey, 0, len(edges))
+	for i := range edges {
+		keys = append(keys, edges[i].GenKey64())
+	}
+	return findKeyCollisions(keys)
+}
+
+// FindTriangleEdgeKeyCollisions finds triangle edges whose EdgeIDs collide
+// Keys are generated from the vertex indices, so stale EdgeIDs do not hide collisions
+func FindTriangleEdgeKeyCollisions(triangles []*Triangle) []EdgeKeyCollision {
+	var keys []EdgeKey
+	for _, t := range triangles {
+		keys = append(keys, t.GenEdgeKeys()...)
+	}
+	return findKeyCollisions(keys)
+}
+
+// findKeyCollisions groups distinct 64-bit keys by their 32-bit key
+func findKeyCollisions(keys []EdgeKey) []EdgeKeyCollision {
+	groups := make(map[int32][]EdgeKey)
+	seen := make(map[EdgeKey]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		legacy := key.Legacy()
+		groups[legacy] = append(groups[legacy], key)
+	}
+
+	var ret []EdgeKeyCollision
+	for legacy, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i] < group[j] })
+		ret = append(ret, EdgeKeyCollision{Key: legacy, Edges: group})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret
+}
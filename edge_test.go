@@ -0,0 +1,99 @@
+package geo
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestEdgeWeightCoord(t *testing.T) {
+	tests := []struct {
+		name string
+		edge Edge
+		want Coord
+	}{
+		{
+			name: "set",
+			edge: Edge{WtCoord: Coord{X: 30, Z: 10}, Vertices: [2]Vertice{{Coord: Coord{X: 0, Z: 10}}, {Coord: Coord{X: 100, Z: 10}}}},
+			want: Coord{X: 30, Z: 10},
+		},
+		{
+			name: "unset",
+			edge: Edge{Vertices: [2]Vertice{{Coord: Coord{X: 0, Z: 10}}, {Coord: Coord{X: 100, Z: 10}}}},
+			want: Coord{X: 50, Z: 10},
+		},
+		{
+			name: "origin on the edge",
+			edge: Edge{Vertices: [2]Vertice{{Coord: Coord{X: -100, Z: 0}}, {Coord: Coord{X: 50, Z: 0}}}},
+			want: Coord{X: 0, Z: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.edge.weightCoord(); got != tt.want {
+				t.Errorf("weightCoord() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEdgeHasInflects(t *testing.T) {
+	tests := []struct {
+		name     string
+		inflects [2]Vertice
+		want     bool
+	}{
+		{name: "unset", inflects: [2]Vertice{}, want: false},
+		{name: "through the origin", inflects: [2]Vertice{{Coord: Coord{X: 0, Z: 0}}, {Coord: Coord{X: 0, Z: 40}}}, want: true},
+		{name: "single point", inflects: [2]Vertice{{Coord: Coord{X: 5, Z: 5}}, {Coord: Coord{X: 5, Z: 5}}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Edge{Inflects: tt.inflects}
+			if got := e.hasInflects(); got != tt.want {
+				t.Errorf("hasInflects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEdgeKeyIndices(t *testing.T) {
+	tests := []struct {
+		name   string
+		i, j   int32
+		lo, hi int32
+	}{
+		{name: "ordered", i: 3, j: 7, lo: 3, hi: 7},
+		{name: "reversed", i: 7, j: 3, lo: 3, hi: 7},
+		{name: "past the legacy limit", i: 10001, j: 20001, lo: 10001, hi: 20001},
+		{name: "negative", i: 5, j: -2, lo: -2, hi: 5},
+		{name: "extremes", i: math.MaxInt32, j: math.MinInt32, lo: math.MinInt32, hi: math.MaxInt32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := GenEdgeKey64(tt.i, tt.j)
+			if lo, hi := key.Indices(); lo != tt.lo || hi != tt.hi {
+				t.Errorf("GenEdgeKey64(%d, %d).Indices() = %d, %d, want %d, %d", tt.i, tt.j, lo, hi, tt.lo, tt.hi)
+			}
+			if rev := GenEdgeKey64(tt.j, tt.i); rev != key {
+				t.Errorf("GenEdgeKey64(%d, %d) = %d, want %d", tt.j, tt.i, rev, key)
+			}
+		})
+	}
+}
+
+func TestFindEdgeKeyCollisions(t *testing.T) {
+	edge := func(i, j int32) Edge {
+		return Edge{Vertices: [2]Vertice{{Index: i}, {Index: j}}}
+	}
+	edges := []Edge{edge(0, 20001), edge(10001, 1), edge(1, 2), edge(20001, 0), edge(2, 3)}
+
+	got := FindEdgeKeyCollisions(edges)
+	want := []EdgeKeyCollision{{Key: 20001, Edges: []EdgeKey{GenEdgeKey64(0, 20001), GenEdgeKey64(1, 10001)}}}
+	if len(got) != len(want) || got[0].Key != want[0].Key || !slices.Equal(got[0].Edges, want[0].Edges) {
+		t.Errorf("FindEdgeKeyCollisions() = %+v, want %+v", got, want)
+	}
+	if got := FindEdgeKeyCollisions(edges[2:3]); len(got) != 0 {
+		t.Errorf("FindEdgeKeyCollisions() without collisions = %+v, want none", got)
+	}
+}
@@ -0,0 +1,223 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+)
+
+// ellipseIterations is the number of bisection steps of the point to ellipse distance
+const ellipseIterations = 64
+
+// Ellipse represents an ellipse with center, semi-axes and rotation
+// An ellipse with a semi-axis of 0 is flat and behaves as the segment along its other axis.
+type Ellipse struct {
+	Center  Coord   // Center coordinate of the ellipse
+	RadiusX int32   // Semi-axis along the local X axis
+	RadiusZ int32   // Semi-axis along the local Z axis
+	Angle   float64 // Rotation of the local axes in radians, counter-clockwise
+}
+
+// NewEllipse creates a new ellipse with given center, semi-axes and rotation
+// Negative semi-axes are clamped to 0.
+func NewEllipse(center Coord, radiusX, radiusZ int32, angle float64) Ellipse {
+	return Ellipse{
+		Center:  center,
+		RadiusX: max(radiusX, 0),
+		RadiusZ: max(radiusZ, 0),
+		Angle:   angle,
+	}
+}
+
+// flat returns the segment a flat ellipse collapses to, false when both semi-axes are positive
+// The segment lies along the local X axis when RadiusX is positive and along the local Z axis otherwise,
+// it is the center alone when both semi-axes are 0.
+func (e *Ellipse) flat() (Segment, bool) {
+	if e.RadiusX > 0 && e.RadiusZ > 0 {
+		return Segment{}, false
+	}
+	cos, sin := math.Cos(e.Angle), math.Sin(e.Angle)
+	half := satPoint{x: -sin * float64(max(e.RadiusZ, 0)), z: cos * float64(max(e.RadiusZ, 0))}
+	if e.RadiusX > 0 {
+		half = satPoint{x: cos * float64(e.RadiusX), z: sin * float64(e.RadiusX)}
+	}
+	center := coordPoint(e.Center)
+	return NewSegment(
+		satCoord(satPoint{x: center.x - half.x, z: center.z - half.z}),
+		satCoord(satPoint{x: center.x + half.x, z: center.z + half.z}),
+	), true
+}
+
+// toLocal returns the point in the local frame of the ellipse
+func (e *Ellipse) toLocal(p Coord) (float64, float64) {
+	dx, dz := float64(p.X-e.Center.X), float64(p.Z-e.Center.Z)
+	cos, sin := math.Cos(e.Angle), math.Sin(e.Angle)
+	return dx*cos + dz*sin, -dx*sin + dz*cos
+}
+
+// toUnit returns the point in the frame where the ellipse is the unit circle
+// Intersections are kept by the mapping, so segments and polygons are tested against the unit circle.
+// Flat ellipses have no such frame and are handled as segments before mapping.
+func (e *Ellipse) toUnit(p Coord) satPoint {
+	x, z := e.toLocal(p)
+	return satPoint{x: x / float64(e.RadiusX), z: z / float64(e.RadiusZ)}
+}
+
+// IsCoordInside checks if point is inside the ellipse, points on the boundary are inside
+func (e *Ellipse) IsCoordInside(p Coord) bool {
+	if seg, ok := e.flat(); ok {
+		return seg.IsCoordInside(p)
+	}
+	u := e.toUnit(p)
+	return u.x*u.x+u.z*u.z <= 1
+}
+
+// CalCoordDst calculates the distance from a point to the ellipse, 0 when the point is inside
+// Reference: https://www.geometrictools.com/Documentation/DistancePointEllipseEllipsoid.pdf
+func (e *Ellipse) CalCoordDst(p Coord) float64 {
+	if seg, ok := e.flat(); ok {
+		return seg.CalCoordDst(p)
+	}
+	if e.IsCoordInside(p) {
+		return 0
+	}
+	x, z := e.toLocal(p)
+	x, z = math.Abs(x), math.Abs(z)
+	a, b := float64(e.RadiusX), float64(e.RadiusZ)
+
+	// The closest point (a²x/(t+a²), b²z/(t+b²)) lies on the ellipse for the root t of
+	// (ax/(t+a²))² + (bz/(t+b²))² = 1, which is in [0, ax+bz] for outside points
+	lo, hi := 0., a*x+b*z
+	for range ellipseIterations {
+		t := (lo + hi) / 2
+		u, v := a*x/(t+a*a), b*z/(t+b*b)
+		if u*u+v*v > 1 {
+			lo = t
+		} else {
+			hi = t
+		}
+	}
+	t := (lo + hi) / 2
+	return math.Hypot(x-a*a*x/(t+a*a), z-b*b*z/(t+b*b))
+}
+
+// IsInterCircle checks if the ellipse intersects with circle
+func (e *Ellipse) IsInterCircle(c *Circle) bool {
+	return e.CalCoordDst(c.Center) <= float64(c.Radius)
+}
+
+// IsInterSegment checks if the ellipse intersects with line segment
+func (e *Ellipse) IsInterSegment(s *Segment) bool {
+	if seg, ok := e.flat(); ok {
+		return seg.IsInterSegment(s)
+	}
+	p := closestOnSegment(e.toUnit(s.A), e.toUnit(s.B), satPoint{})
+	return p.x*p.x+p.z*p.z <= 1
+}
+
+// IsInterConvex checks if the ellipse intersects with a convex polygon
+func (e *Ellipse) IsInterConvex(p ConvexPolygon) bool {
+	coords := p.GetCoords()
+	if NewSimplePolygon(coords, 0).IsCoordInside(e.Center) {
+		return true
+	}
+	for i := range coords {
+		seg := NewSegment(coords[i], coords[(i+1)%len(coords)])
+		if e.IsInterSegment(&seg) {
+			return true
+		}
+	}
+	return false
+}
+
+// Support returns the farthest point of the ellipse in the direction
+func (e *Ellipse) Support(dir Vector) Coord {
+	if dir.X == 0 && dir.Z == 0 {
+		return e.Center
+	}
+	if seg, ok := e.flat(); ok {
+		return seg.Support(dir)
+	}
+	cos, sin := math.Cos(e.Angle), math.Sin(e.Angle)
+	dx, dz := float64(dir.X), float64(dir.Z)
+	lx, lz := dx*cos+dz*sin, -dx*sin+dz*cos
+	a, b := float64(e.RadiusX), float64(e.RadiusZ)
+	length := math.Hypot(a*lx, b*lz)
+	if length == 0 {
+		return e.Center
+	}
+	x, z := a*a*lx/length, b*b*lz/length
+	return Coord{
+		X: e.Center.X + int32(math.Round(x*cos-z*sin)),
+		Z: e.Center.Z + int32(math.Round(x*sin+z*cos)),
+	}
+}
+
+// farthestCoord returns the point of the ellipse farthest from p
+func (e *Ellipse) farthestCoord(p Coord) Coord {
+	if seg, ok := e.flat(); ok {
+		if CalDstCoordToCoordWithoutSqrt(p, seg.B) > CalDstCoordToCoordWithoutSqrt(p, seg.A) {
+			return seg.B
+		}
+		return seg.A
+	}
+	x, z := e.toLocal(p)
+	a, b := float64(e.RadiusX), float64(e.RadiusZ)
+	// The farthest point lies in the quadrant opposite to p, searched by its parametric angle
+	sx, sz := -1., -1.
+	if x < 0 {
+		sx = 1
+	}
+	if z < 0 {
+		sz = 1
+	}
+	dst := func(theta float64) float64 {
+		return math.Hypot(sx*a*math.Cos(theta)-x, sz*b*math.Sin(theta)-z)
+	}
+	lo, hi := 0., math.Pi/2
+	for range ellipseIterations {
+		m1, m2 := lo+(hi-lo)/3, hi-(hi-lo)/3
+		if dst(m1) < dst(m2) {
+			lo = m1
+		} else {
+			hi = m2
+		}
+	}
+	theta := (lo + hi) / 2
+	fx, fz := sx*a*math.Cos(theta), sz*b*math.Sin(theta)
+	cos, sin := math.Cos(e.Angle), math.Sin(e.Angle)
+	return Coord{
+		X: e.Center.X + int32(math.Round(fx*cos-fz*sin)),
+		Z: e.Center.Z + int32(math.Round(fx*sin+fz*cos)),
+	}
+}
+
+// RandCoord generates a random coordinate uniformly distributed within the ellipse
+// r: source of randomness, the global source of math/rand is used when nil
+func (e *Ellipse) RandCoord(r *rand.Rand) Coord {
+	// Uniform point in the unit disk stretched along both axes
+	dst := math.Sqrt(randFloat(r))
+	angle := randFloat(r) * 2 * math.Pi
+	x := dst * math.Cos(angle) * float64(e.RadiusX)
+	z := dst * math.Sin(angle) * float64(e.RadiusZ)
+	cos, sin := math.Cos(e.Angle), math.Sin(e.Angle)
+	return Coord{
+		X: e.Center.X + int32(math.Round(x*cos-z*sin)),
+		Z: e.Center.Z + int32(math.Round(x*sin+z*cos)),
+	}
+}
+
+// ToRect returns the bounding rectangle of the ellipse
+// Returns the minimum and maximum X,Z coordinates of the bounding rectangle
+func (e *Ellipse) ToRect() (minX, minZ, maxX, maxZ int32) {
+	a, b := float64(e.RadiusX), float64(e.RadiusZ)
+	cos, sin := math.Cos(e.Angle), math.Sin(e.Angle)
+	halfX := int32(math.Ceil(math.Sqrt(a*a*cos*cos + b*b*sin*sin)))
+	halfZ := int32(math.Ceil(math.Sqrt(a*a*sin*sin + b*b*cos*cos)))
+	return e.Center.X - halfX, e.Center.Z - halfZ, e.Center.X + halfX, e.Center.Z + halfZ
+}
+
+// GetLocationToBorder returns the relative position between ellipse and border
+func (e *Ellipse) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := e.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEllipseIsCoordInside(t *testing.T) {
+	tests := []struct {
+		name    string
+		ellipse Ellipse
+		p       Coord
+		want    bool
+	}{
+		{name: "vertex", ellipse: NewEllipse(Coord{}, 100, 50, 0), p: Coord{X: 100, Z: 0}, want: true},
+		{name: "co-vertex", ellipse: NewEllipse(Coord{}, 100, 50, 0), p: Coord{X: 0, Z: 50}, want: true},
+		{name: "past the co-vertex", ellipse: NewEllipse(Coord{}, 100, 50, 0), p: Coord{X: 0, Z: 51}, want: false},
+		{name: "rotated", ellipse: NewEllipse(Coord{X: 10, Z: 10}, 100, 50, math.Pi/2), p: Coord{X: 10, Z: 110}, want: true},
+		{name: "rotated outside", ellipse: NewEllipse(Coord{X: 10, Z: 10}, 100, 50, math.Pi/2), p: Coord{X: 110, Z: 10}, want: false},
+		{name: "flat on the segment", ellipse: NewEllipse(Coord{}, 100, 0, 0), p: Coord{X: -60, Z: 0}, want: true},
+		{name: "flat off the segment", ellipse: NewEllipse(Coord{}, 100, 0, 0), p: Coord{X: 50, Z: 1}, want: false},
+		{name: "flat along Z", ellipse: NewEllipse(Coord{}, 0, 100, 0), p: Coord{X: 0, Z: 70}, want: true},
+		{name: "point", ellipse: NewEllipse(Coord{X: 5, Z: 5}, 0, 0, 0), p: Coord{X: 5, Z: 5}, want: true},
+		{name: "negative semi-axis", ellipse: NewEllipse(Coord{}, -100, 50, 0), p: Coord{X: 0, Z: 30}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ellipse.IsCoordInside(tt.p); got != tt.want {
+				t.Errorf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEllipseCalCoordDst(t *testing.T) {
+	tests := []struct {
+		name    string
+		ellipse Ellipse
+		p       Coord
+		want    float64
+	}{
+		{name: "inside", ellipse: NewEllipse(Coord{}, 100, 50, 0), p: Coord{X: 10, Z: 10}, want: 0},
+		{name: "along X", ellipse: NewEllipse(Coord{}, 100, 50, 0), p: Coord{X: 200, Z: 0}, want: 100},
+		{name: "along Z", ellipse: NewEllipse(Coord{}, 100, 50, 0), p: Coord{X: 0, Z: -80}, want: 30},
+		{name: "flat", ellipse: NewEllipse(Coord{}, 100, 0, 0), p: Coord{X: 50, Z: 10}, want: 10},
+		{name: "flat past the end", ellipse: NewEllipse(Coord{}, 100, 0, 0), p: Coord{X: 130, Z: 40}, want: 50},
+		{name: "point", ellipse: NewEllipse(Coord{}, 0, 0, 0), p: Coord{X: 3, Z: 4}, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ellipse.CalCoordDst(tt.p); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("CalCoordDst(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEllipseFlatShapes(t *testing.T) {
+	flat := NewEllipse(Coord{}, 0, 100, 0)
+	crossing := NewSegment(Coord{X: -50, Z: 20}, Coord{X: 50, Z: 20})
+	if !Intersects(&flat, &crossing) {
+		t.Error("Intersects() of a flat ellipse and a crossing segment = false, want true")
+	}
+	beside := NewCirCle(Coord{X: 30, Z: 0}, 20)
+	if Intersects(&flat, &beside) {
+		t.Error("Intersects() of a flat ellipse and a circle beside it = true, want false")
+	}
+	ray := NewRay(Coord{X: -100, Z: 50}, Vector{X: 1, Z: 0})
+	if hit, ok := ray.Cast(&flat); !ok || hit.Coord != (Coord{X: 0, Z: 50}) {
+		t.Errorf("Cast() = %+v, %v, want a hit at {0 50}", hit, ok)
+	}
+	r := rand.New(rand.NewSource(1))
+	for range 100 {
+		if p := flat.RandCoord(r); !flat.IsCoordInside(p) {
+			t.Fatalf("RandCoord() = %v outside the flat ellipse", p)
+		}
+	}
+}
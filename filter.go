@@ -0,0 +1,83 @@
+package geo
+
+// QueryFilter selects the polygons navmesh queries may enter and the cost of crossing them
+// A polygon passes when it holds one of IncludeFlags, or IncludeFlags is 0, and none of ExcludeFlags.
+// Polygons other than Triangle and Convex have area type 0 and no flags.
+// A nil filter lets every polygon pass at cost 1.
+// Reference: https://github.com/recastnavigation/recastnavigation/blob/main/Detour/Include/DetourNavMeshQuery.h
+type QueryFilter struct {
+	IncludeFlags uint16            // Flags of which a polygon needs one, 0 accepts any flags
+	ExcludeFlags uint16            // Flags of which a polygon must have none
+	AreaCosts    map[uint8]float64 // Positive cost multiplier per area type, 1 for missing or non-positive costs
+}
+
+// NewQueryFilter creates a new query filter with given include and exclude flags
+func NewQueryFilter(includeFlags, excludeFlags uint16) *QueryFilter {
+	return &QueryFilter{
+		IncludeFlags: includeFlags,
+		ExcludeFlags: excludeFlags,
+		AreaCosts:    make(map[uint8]float64),
+	}
+}
+
+// SetAreaCost sets the cost multiplier of the area type
+// A cost that is not positive, including NaN, would let A* loop or drop its heuristic,
+// so it resets the area type to cost 1 instead.
+func (f *QueryFilter) SetAreaCost(areaType uint8, cost float64) {
+	if !(cost > 0) {
+		delete(f.AreaCosts, areaType)
+		return
+	}
+	if f.AreaCosts == nil {
+		f.AreaCosts = make(map[uint8]float64)
+	}
+	f.AreaCosts[areaType] = cost
+}
+
+// PassPolygon checks if queries may enter the polygon
+func (f *QueryFilter) PassPolygon(poly Polygon) bool {
+	if f == nil {
+		return true
+	}
+	_, flags := polygonArea(poly)
+	if f.IncludeFlags != 0 && flags&f.IncludeFlags == 0 {
+		return false
+	}
+	return flags&f.ExcludeFlags == 0
+}
+
+// AreaCost returns the cost multiplier of moving through the polygon
+func (f *QueryFilter) AreaCost(poly Polygon) float64 {
+	if f == nil {
+		return 1
+	}
+	areaType, _ := polygonArea(poly)
+	if cost, ok := f.AreaCosts[areaType]; ok && cost > 0 {
+		return cost
+	}
+	return 1
+}
+
+// minCost returns the smallest cost multiplier, scaling the A* heuristic so that it never overestimates
+func (f *QueryFilter) minCost() float64 {
+	ret := 1.
+	if f != nil {
+		for _, cost := range f.AreaCosts {
+			if cost > 0 {
+				ret = min(ret, cost)
+			}
+		}
+	}
+	return ret
+}
+
+// polygonArea returns the area type and flags of the polygon
+func polygonArea(poly Polygon) (uint8, uint16) {
+	switch p := poly.(type) {
+	case *Triangle:
+		return p.AreaType, p.Flags
+	case *Convex:
+		return p.AreaType, p.Flags
+	}
+	return 0, 0
+}
@@ -0,0 +1,142 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestQueryFilterFindPath(t *testing.T) {
+	// A direct corridor through the middle row and a detour through the top row
+	m := newSquareMesh(t,
+		Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}, Coord{X: 200, Z: 0},
+		Coord{X: 0, Z: 100}, Coord{X: 100, Z: 100}, Coord{X: 200, Z: 100},
+	)
+	for _, poly := range m.Polygons {
+		if tri := poly.(*Triangle); tri.Vertices[0].Coord.X == 100 && tri.Vertices[0].Coord.Z == 0 {
+			tri.Flags = 1
+		}
+	}
+	start, end := Coord{X: 50, Z: 50}, Coord{X: 250, Z: 50}
+
+	path, err := m.FindPath(start, end, NewQueryFilter(0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Coord{start, {X: 100, Z: 100}, {X: 200, Z: 100}, end}
+	if !slices.Equal(path, want) {
+		t.Errorf("FindPath() with excluded middle = %v, want %v", path, want)
+	}
+
+	bottom := newSquareMesh(t, Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}, Coord{X: 200, Z: 0})
+	for _, poly := range bottom.Polygons {
+		poly.(*Triangle).Flags = 1
+	}
+	if _, err := bottom.FindPath(start, end, NewQueryFilter(0, 1)); !errors.Is(err, ErrCoordNotOnMesh) {
+		t.Errorf("FindPath() on excluded polygons error = %v, want %v", err, ErrCoordNotOnMesh)
+	}
+}
+
+func TestQueryFilterRaycastAndNearest(t *testing.T) {
+	m := newSquareMesh(t, Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}, Coord{X: 200, Z: 0})
+	for _, poly := range m.Polygons {
+		if tri := poly.(*Triangle); tri.Vertices[0].Coord.X == 100 {
+			tri.Flags = 1
+		}
+	}
+	filter := NewQueryFilter(0, 1)
+
+	hit, err := m.Raycast(Coord{X: 50, Z: 50}, Coord{X: 250, Z: 50}, nil)
+	if err != nil || !hit.Reached {
+		t.Errorf("Raycast() without filter = %+v, %v, want reached", hit, err)
+	}
+	hit, err = m.Raycast(Coord{X: 50, Z: 50}, Coord{X: 250, Z: 50}, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hit.Reached || hit.Coord != (Coord{X: 100, Z: 50}) {
+		t.Errorf("Raycast() with filter = %+v, want blocked at {100 50}", hit)
+	}
+
+	p, _, err := m.FindNearestCoord(Coord{X: 140, Z: 50}, 100, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != (Coord{X: 100, Z: 50}) {
+		t.Errorf("FindNearestCoord() with filter = %v, want {100 50}", p)
+	}
+	if _, _, err := m.FindNearestCoord(Coord{X: 150, Z: 50}, 10, filter); !errors.Is(err, ErrCoordNotOnMesh) {
+		t.Errorf("FindNearestCoord() within excluded polygons error = %v, want %v", err, ErrCoordNotOnMesh)
+	}
+}
+
+func TestQueryFilterAreaCost(t *testing.T) {
+	// The bottom row is a swamp, the top row is a detour around it
+	m := newSquareMesh(t,
+		Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}, Coord{X: 200, Z: 0},
+		Coord{X: 0, Z: 100}, Coord{X: 100, Z: 100}, Coord{X: 200, Z: 100},
+	)
+	for _, poly := range m.Polygons {
+		if tri := poly.(*Triangle); tri.Vertices[0].Coord.X == 100 && tri.Vertices[0].Coord.Z == 0 {
+			tri.AreaType = 1
+		}
+	}
+	start, end := Coord{X: 50, Z: 10}, Coord{X: 250, Z: 10}
+
+	path, err := m.FindPath(start, end, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Coord{start, end}; !slices.Equal(path, want) {
+		t.Errorf("FindPath() without costs = %v, want %v", path, want)
+	}
+
+	filter := NewQueryFilter(0, 0)
+	filter.SetAreaCost(1, 10)
+	path, err = m.FindPath(start, end, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Coord{start, {X: 100, Z: 100}, {X: 200, Z: 100}, end}; !slices.Equal(path, want) {
+		t.Errorf("FindPath() around the swamp = %v, want %v", path, want)
+	}
+}
+
+func TestQueryFilterNonPositiveCost(t *testing.T) {
+	tri := &Triangle{AreaType: 1}
+	tests := []struct {
+		name string
+		cost float64
+	}{
+		{name: "zero", cost: 0},
+		{name: "negative", cost: -5},
+		{name: "NaN", cost: math.NaN()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewQueryFilter(0, 0)
+			filter.SetAreaCost(1, 10)
+			filter.SetAreaCost(1, tt.cost)
+			if got := filter.AreaCost(tri); got != 1 {
+				t.Errorf("AreaCost() after SetAreaCost(%v) = %v, want 1", tt.cost, got)
+			}
+			filter.AreaCosts[2] = tt.cost
+			if got := filter.minCost(); got != 1 {
+				t.Errorf("minCost() with cost %v in AreaCosts = %v, want 1", tt.cost, got)
+			}
+		})
+	}
+
+	m := newSquareMesh(t, Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}, Coord{X: 200, Z: 0})
+	filter := NewQueryFilter(0, 0)
+	filter.AreaCosts[0] = -1
+	start, end := Coord{X: 50, Z: 50}, Coord{X: 250, Z: 50}
+	path, err := m.FindPath(start, end, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Coord{start, end}; !slices.Equal(path, want) {
+		t.Errorf("FindPath() with a negative cost = %v, want %v", path, want)
+	}
+}
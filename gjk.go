@@ -0,0 +1,282 @@
+package geo
+
+import (
+	"math"
+)
+
+const (
+	gjkMaxIterations = 64      // Iteration limit of GJK and EPA
+	gjkDirScale      = 1000000 // Length of the directions passed to Support
+	gjkTolerance     = 1e-6    // Convergence tolerance relative to the distance
+	epaTolerance     = 1       // Convergence tolerance of EPA, supports are rounded to coordinates
+)
+
+// Supporter represents a convex shape described by its support function
+// Every Shape implements it, non-convex shapes through their convex hull.
+type Supporter interface {
+	Support(dir Vector) Coord // Farthest point of the shape in the direction, dir does not need to be normalized
+}
+
+// GJKDistance calculates the distance and the closest points between two convex shapes with GJK
+// Returns false when the shapes overlap, use GJKPenetration to resolve them
+// Reference: https://en.wikipedia.org/wiki/Gilbert%E2%80%93Johnson%E2%80%93Keerthi_distance_algorithm
+func GJKDistance(a, b Supporter) (dst float64, closestA, closestB Coord, ok bool) {
+	s, overlap := gjk(a, b)
+	if overlap {
+		return 0, Coord{}, Coord{}, false
+	}
+	pa, pb := s.closestPoints()
+	return math.Hypot(pa.x-pb.x, pa.z-pb.z), satCoord(pa), satCoord(pb), true
+}
+
+// GJKPenetration checks if two convex shapes overlap with GJK and measures the penetration with EPA
+// The contact normal points from a to b, Coord is the deepest point of a inside b
+// Reference: https://dyn4j.org/2010/05/epa-expanding-polytope-algorithm/
+func GJKPenetration(a, b Supporter) (Contact, bool) {
+	s, overlap := gjk(a, b)
+	if !overlap {
+		return Contact{}, false
+	}
+	return epa(a, b, s), true
+}
+
+// gjkVertex represents a point of the Minkowski difference a - b with the support points it comes from
+type gjkVertex struct {
+	w, a, b satPoint
+}
+
+// gjkSupport returns the support point of the Minkowski difference a - b in the direction
+func gjkSupport(a, b Supporter, dir satPoint) gjkVertex {
+	length := math.Hypot(dir.x, dir.z)
+	d := Vector{
+		X: int32(math.Round(dir.x / length * gjkDirScale)),
+		Z: int32(math.Round(dir.z / length * gjkDirScale)),
+	}
+	pa := a.Support(d)
+	pb := b.Support(Vector{X: -d.X, Z: -d.Z})
+	v := gjkVertex{
+		a: satPoint{x: float64(pa.X), z: float64(pa.Z)},
+		b: satPoint{x: float64(pb.X), z: float64(pb.Z)},
+	}
+	v.w = satPoint{x: v.a.x - v.b.x, z: v.a.z - v.b.z}
+	return v
+}
+
+// gjkSimplex represents the simplex of GJK with the barycentric weights of its closest point to the origin
+type gjkSimplex struct {
+	vertices []gjkVertex
+	weights  []float64
+}
+
+// closest returns the point of the simplex closest to the origin
+func (s *gjkSimplex) closest() satPoint {
+	var p satPoint
+	for i, v := range s.vertices {
+		p.x += s.weights[i] * v.w.x
+		p.z += s.weights[i] * v.w.z
+	}
+	return p
+}
+
+// closestPoints returns the closest points on both shapes
+func (s *gjkSimplex) closestPoints() (satPoint, satPoint) {
+	var pa, pb satPoint
+	for i, v := range s.vertices {
+		pa.x += s.weights[i] * v.a.x
+		pa.z += s.weights[i] * v.a.z
+		pb.x += s.weights[i] * v.b.x
+		pb.z += s.weights[i] * v.b.z
+	}
+	return pa, pb
+}
+
+// reduce keeps the smallest sub-simplex containing the closest point to the origin
+// Returns true when the origin is inside the simplex
+func (s *gjkSimplex) reduce() bool {
+	switch len(s.vertices) {
+	case 1:
+		s.weights = []float64{1}
+	case 2:
+		s.reduceSegment(0, 1)
+	case 3:
+		a, b, c := s.vertices[0].w, s.vertices[1].w, s.vertices[2].w
+		area := (b.x-a.x)*(c.z-a.z) - (b.z-a.z)*(c.x-a.x)
+		if area != 0 {
+			// Signed areas of the sub-triangles with the origin give the barycentric weights
+			u := (b.x*c.z - b.z*c.x) / area
+			v := (c.x*a.z - c.z*a.x) / area
+			w := (a.x*b.z - a.z*b.x) / area
+			if u >= 0 && v >= 0 && w >= 0 {
+				s.weights = []float64{u, v, w}
+				return true
+			}
+		}
+		// The closest point is on the nearest edge
+		best := -1.
+		var vertices []gjkVertex
+		var weights []float64
+		for _, edge := range [3][2]int{{0, 1}, {1, 2}, {2, 0}} {
+			sub := gjkSimplex{vertices: []gjkVertex{s.vertices[edge[0]], s.vertices[edge[1]]}}
+			sub.reduceSegment(0, 1)
+			p := sub.closest()
+			if d := p.x*p.x + p.z*p.z; best < 0 || d < best {
+				best, vertices, weights = d, sub.vertices, sub.weights
+			}
+		}
+		s.vertices, s.weights = vertices, weights
+	}
+	p := s.closest()
+	return p.x == 0 && p.z == 0
+}
+
+// reduceSegment reduces the simplex to the segment i, j or one of its endpoints
+func (s *gjkSimplex) reduceSegment(i, j int) {
+	a, b := s.vertices[i], s.vertices[j]
+	dx, dz := b.w.x-a.w.x, b.w.z-a.w.z
+	lengthSquared := dx*dx + dz*dz
+	t := 0.
+	if lengthSquared > 0 {
+		t = -(a.w.x*dx + a.w.z*dz) / lengthSquared
+	}
+	switch {
+	case t <= 0:
+		s.vertices, s.weights = []gjkVertex{a}, []float64{1}
+	case t >= 1:
+		s.vertices, s.weights = []gjkVertex{b}, []float64{1}
+	default:
+		s.vertices, s.weights = []gjkVertex{a, b}, []float64{1 - t, t}
+	}
+}
+
+// gjk runs GJK on the Minkowski difference a - b
+// Returns the final simplex and true when it contains the origin
+func gjk(a, b Supporter) (*gjkSimplex, bool) {
+	s := &gjkSimplex{vertices: []gjkVertex{gjkSupport(a, b, satPoint{x: 1})}}
+	for range gjkMaxIterations {
+		if s.reduce() {
+			return s, true
+		}
+		p := s.closest()
+		dstSquared := p.x*p.x + p.z*p.z
+		v := gjkSupport(a, b, satPoint{x: -p.x, z: -p.z})
+		// No support point is closer to the origin than the current closest point
+		if dstSquared-(p.x*v.w.x+p.z*v.w.z) <= gjkTolerance*dstSquared {
+			return s, false
+		}
+		for _, u := range s.vertices {
+			if u.w == v.w {
+				return s, false
+			}
+		}
+		s.vertices = append(s.vertices, v)
+	}
+	return s, false
+}
+
+// epa expands the simplex containing the origin until the boundary of a - b closest to the origin is found
+func epa(a, b Supporter, s *gjkSimplex) Contact {
+	polytope := append([]gjkVertex(nil), s.vertices...)
+	switch len(polytope) {
+	case 1:
+		// The origin is the farthest point of a - b along the first support direction
+		return Contact{Normal: satVector(satPoint{x: 1}), Coord: satCoord(polytope[0].a)}
+	case 2:
+		// The origin is on a segment, the simplex is completed on either side of it
+		v1, v2 := polytope[0].w, polytope[1].w
+		length := math.Hypot(v2.x-v1.x, v2.z-v1.z)
+		n := satPoint{x: (v2.z - v1.z) / length, z: -(v2.x - v1.x) / length}
+		for _, dir := range []satPoint{n, {x: -n.x, z: -n.z}} {
+			if v := gjkSupport(a, b, dir); dir.x*v.w.x+dir.z*v.w.z > gjkTolerance {
+				polytope = append(polytope, v)
+				break
+			}
+		}
+		if len(polytope) < 3 {
+			pa, _ := s.closestPoints()
+			return Contact{Normal: satVector(n), Coord: satCoord(pa)}
+		}
+	}
+	if epaArea(polytope) < 0 {
+		polytope[0], polytope[1] = polytope[1], polytope[0]
+	}
+
+	var edge int
+	var normal satPoint
+	var dst float64
+	for range gjkMaxIterations {
+		edge, normal, dst = epaClosestEdge(polytope)
+		v := gjkSupport(a, b, normal)
+		if normal.x*v.w.x+normal.z*v.w.z-dst <= epaTolerance || epaContains(polytope, v) {
+			break
+		}
+		polytope = append(polytope[:edge+1], append([]gjkVertex{v}, polytope[edge+1:]...)...)
+	}
+
+	// The projection of the origin on the closest edge gives the contact point on a
+	v1, v2 := polytope[edge], polytope[(edge+1)%len(polytope)]
+	dx, dz := v2.w.x-v1.w.x, v2.w.z-v1.w.z
+	t := 0.
+	if lengthSquared := dx*dx + dz*dz; lengthSquared > 0 {
+		t = max(0, min(1, -(v1.w.x*dx+v1.w.z*dz)/lengthSquared))
+	}
+	contact := satPoint{x: v1.a.x + t*(v2.a.x-v1.a.x), z: v1.a.z + t*(v2.a.z-v1.a.z)}
+	return Contact{
+		Normal: satVector(normal),
+		Depth:  max(dst, 0),
+		Coord:  satCoord(contact),
+	}
+}
+
+// epaClosestEdge returns the edge of the counter-clockwise polytope closest to the origin
+// with its outward unit normal and distance
+func epaClosestEdge(polytope []gjkVertex) (int, satPoint, float64) {
+	edge := 0
+	normal := satPoint{}
+	dst := math.Inf(1)
+	for i := range polytope {
+		a, b := polytope[i].w, polytope[(i+1)%len(polytope)].w
+		dx, dz := b.x-a.x, b.z-a.z
+		length := math.Hypot(dx, dz)
+		if length == 0 {
+			continue
+		}
+		n := satPoint{x: dz / length, z: -dx / length}
+		if d := n.x*a.x + n.z*a.z; d < dst {
+			edge, normal, dst = i, n, d
+		}
+	}
+	return edge, normal, dst
+}
+
+// epaArea returns twice the signed area of the polytope, positive for counter-clockwise
+func epaArea(polytope []gjkVertex) float64 {
+	var area float64
+	for i := range polytope {
+		a, b := polytope[i].w, polytope[(i+1)%len(polytope)].w
+		area += a.x*b.z - b.x*a.z
+	}
+	return area
+}
+
+// epaContains checks if the polytope already has the point
+func epaContains(polytope []gjkVertex, v gjkVertex) bool {
+	for _, u := range polytope {
+		if u.w == v.w {
+			return true
+		}
+	}
+	return false
+}
+
+// supportCoords returns the coordinate farthest in the direction
+func supportCoords(coords []Coord, dir Vector) Coord {
+	best := coords[0]
+	bestDot := math.Inf(-1)
+	for _, p := range coords {
+		v := NewVectorByCoord(p)
+		if dot := v.Dot(&dir); dot > bestDot {
+			best, bestDot = p, dot
+		}
+	}
+	return best
+}
@@ -0,0 +1,117 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGJKDistance(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      Supporter
+		want      float64
+		tolerance float64 // Supports are rounded to coordinates
+	}{
+		{
+			name: "rectangles side by side",
+			a:    &Rectangle{Coord: Coord{X: 0, Z: 0}, Width: 100, Height: 100},
+			b:    &Rectangle{Coord: Coord{X: 150, Z: 20}, Width: 100, Height: 100},
+			want: 50,
+		},
+		{
+			name:      "circles",
+			a:         &Circle{Center: Coord{X: 0, Z: 0}, Radius: 100},
+			b:         &Circle{Center: Coord{X: 300, Z: 0}, Radius: 50},
+			want:      150,
+			tolerance: 1,
+		},
+		{
+			name:      "rectangle corner and circle",
+			a:         &Rectangle{Coord: Coord{X: 0, Z: 0}, Width: 100, Height: 100},
+			b:         &Circle{Center: Coord{X: 200, Z: 200}, Radius: 50},
+			want:      100*math.Sqrt2 - 50,
+			tolerance: 1,
+		},
+		{
+			name: "segment and triangle",
+			a:    &Segment{A: Coord{X: 0, Z: 100}, B: Coord{X: 100, Z: 100}},
+			b:    &Triangle{Vertices: []Vertice{{Coord: Coord{X: 0, Z: 0}}, {Coord: Coord{X: 50, Z: 40}}, {Coord: Coord{X: 100, Z: 0}}}},
+			want: 60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst, _, _, ok := GJKDistance(tt.a, tt.b)
+			if !ok {
+				t.Fatal("GJKDistance() reports an overlap")
+			}
+			if math.Abs(dst-tt.want) > tt.tolerance+gjkTolerance {
+				t.Errorf("GJKDistance() = %v, want %v", dst, tt.want)
+			}
+		})
+	}
+
+	a := &Rectangle{Coord: Coord{X: 0, Z: 0}, Width: 100, Height: 100}
+	b := &Rectangle{Coord: Coord{X: 50, Z: 50}, Width: 100, Height: 100}
+	if _, _, _, ok := GJKDistance(a, b); ok {
+		t.Error("GJKDistance() of overlapping rectangles reports no overlap")
+	}
+}
+
+func TestGJKPenetration(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Supporter
+		depth  float64
+		normal Vector
+		angle  float64 // Normal tolerance in radians
+	}{
+		{
+			name:   "rectangles overlapping along X",
+			a:      &Rectangle{Coord: Coord{X: 0, Z: 0}, Width: 100, Height: 100},
+			b:      &Rectangle{Coord: Coord{X: 80, Z: 10}, Width: 100, Height: 50},
+			depth:  20,
+			normal: Vector{X: 1000, Z: 0},
+			angle:  0.01,
+		},
+		{
+			name:   "rectangles overlapping along Z",
+			a:      &Rectangle{Coord: Coord{X: 0, Z: 0}, Width: 100, Height: 100},
+			b:      &Rectangle{Coord: Coord{X: 20, Z: -70}, Width: 50, Height: 100},
+			depth:  30,
+			normal: Vector{X: 0, Z: -1000},
+			angle:  0.01,
+		},
+		{
+			name:   "circles",
+			a:      &Circle{Center: Coord{X: 0, Z: 0}, Radius: 100},
+			b:      &Circle{Center: Coord{X: 150, Z: 0}, Radius: 100},
+			depth:  50,
+			normal: Vector{X: 1000, Z: 0},
+			// EPA stops once the depth 200 - 150*cos(angle) is within epaTolerance
+			angle: math.Acos((150 - epaTolerance) / 150),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := GJKPenetration(tt.a, tt.b)
+			if !ok {
+				t.Fatal("GJKPenetration() reports no overlap")
+			}
+			if math.Abs(c.Depth-tt.depth) > epaTolerance {
+				t.Errorf("GJKPenetration() depth = %v, want %v", c.Depth, tt.depth)
+			}
+			got := math.Atan2(float64(c.Normal.Z), float64(c.Normal.X))
+			want := math.Atan2(float64(tt.normal.Z), float64(tt.normal.X))
+			if math.Abs(math.Remainder(got-want, 2*math.Pi)) > tt.angle {
+				t.Errorf("GJKPenetration() normal = %v, want %v", c.Normal, tt.normal)
+			}
+		})
+	}
+
+	a := &Circle{Center: Coord{X: 0, Z: 0}, Radius: 100}
+	b := &Circle{Center: Coord{X: 300, Z: 0}, Radius: 100}
+	if _, ok := GJKPenetration(a, b); ok {
+		t.Error("GJKPenetration() of separated circles reports an overlap")
+	}
+}
@@ -0,0 +1,516 @@
+package geo
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrCoordNotOnMesh is returned when a coordinate is not inside any polygon of the mesh
+	ErrCoordNotOnMesh = errors.New("geo: coordinate is not on the navmesh")
+	// ErrPathNotFound is returned when no polygon path connects the start and end polygons
+	ErrPathNotFound = errors.New("geo: path not found")
+	// ErrNoWalkableCoord is returned when random sampling finds no walkable point
+	ErrNoWalkableCoord = errors.New("geo: no walkable coordinate found")
+	// ErrInconsistentMesh is returned when a walk across the mesh comes back to a polygon it already crossed,
+	// which only happens with overlapping polygons or links between polygons that do not share the edge
+	ErrInconsistentMesh = errors.New("geo: inconsistent navmesh")
+)
+
+// navLink represents a portal from one polygon to a neighboring polygon
+type navLink struct {
+	to     int      // Slot of the neighboring polygon
+	edge   *Edge    // Shared edge, nil when the portal was derived from polygon vertices
+	portal [2]Coord // Portal endpoints
+	wt     Coord    // Weight coordinate of the portal, used as the A* node position
+}
+
+// NavMesh represents a navigation mesh made of polygons connected through shared edges
+// Polygons and Edges are read-only after NewNavMesh, the links, spatial index and sampler are built from them.
+type NavMesh struct {
+	Polygons []Polygon // Walkable polygons (triangles or convex polygons), read-only
+	Edges    []Edge    // Edges of the mesh, adjacency edges become portals, read-only
+
+	slots    map[int32]int     // Polygon index to slot in Polygons
+	centers  []Coord           // Center coordinate of each polygon
+	links    [][]navLink       // Portals leaving each polygon
+	tree     *PolygonRTree     // Spatial index for point location
+	edgeKeys map[EdgeKey]*Edge // Edges by vertex indices
+	sampler  *PolygonSampler   // Area-weighted random point sampling
+}
+
+// NewNavMesh creates a navigation mesh from polygons and edges
+// Adjacency edges connect the polygons owning their two adjacent triangles,
+// a Convex owns every triangle in MergeTriangles and a Triangle owns itself.
+// When no edges are given, polygons sharing an edge are linked through GetNeighborPoints.
+// Convex polygons from DecomposeConvex may share several collinear edges, or collinear vertices
+// which GetNeighborPoints does not report, these are linked through one portal spanning them all.
+// Edge.Inflects narrow the portal when they are two distinct points, Edge.WtCoord is the midpoint
+// of the edge when it is the origin and the edge does not pass through the origin.
+func NewNavMesh(polygons []Polygon, edges []Edge) *NavMesh {
+	m := &NavMesh{
+		Polygons: polygons,
+		Edges:    edges,
+		slots:    make(map[int32]int, len(polygons)),
+		centers:  make([]Coord, len(polygons)),
+		links:    make([][]navLink, len(polygons)),
+		tree:     NewPolygonRTree(polygons, rtreeNodeCapacity),
+		edgeKeys: IndexEdges(edges),
+		sampler:  NewPolygonSampler(polygons),
+	}
+
+	owners := make(map[int32]int, len(polygons))
+	for slot, poly := range polygons {
+		if _, exist := m.slots[poly.GetIndex()]; !exist {
+			m.slots[poly.GetIndex()] = slot
+		}
+		m.centers[slot] = calVerticesCenter(poly.GetVertices())
+		switch p := poly.(type) {
+		case *Convex:
+			for _, t := range p.MergeTriangles {
+				owners[t.Index] = slot
+			}
+		case *Triangle:
+			owners[p.Index] = slot
+		}
+	}
+
+	if len(edges) == 0 {
+		m.linkByVertices()
+		return m
+	}
+
+	for i := range edges {
+		e := &edges[i]
+		if !e.IsAdjacency || len(e.AdjacenctTriangles) != 2 {
+			continue
+		}
+		from, ok1 := owners[e.AdjacenctTriangles[0].Index]
+		to, ok2 := owners[e.AdjacenctTriangles[1].Index]
+		// Edges inside a merged convex polygon are not portals
+		if !ok1 || !ok2 || from == to {
+			continue
+		}
+		portal := [2]Coord{e.Vertices[0].Coord, e.Vertices[1].Coord}
+		// Inflection points narrow the portal when they are generated
+		if e.hasInflects() {
+			portal = [2]Coord{e.Inflects[0].Coord, e.Inflects[1].Coord}
+		}
+		wt := e.weightCoord()
+		if m.extendLink(from, to, portal) {
+			continue
+		}
+		m.links[from] = append(m.links[from], navLink{to: to, edge: e, portal: portal, wt: wt})
+		m.links[to] = append(m.links[to], navLink{to: from, edge: e, portal: portal, wt: wt})
+	}
+	return m
+}
+
+// extendLink merges a collinear portal into an existing link between the same polygons
+// Merged convex polygons may share several collinear edges which form one portal
+func (m *NavMesh) extendLink(from, to int, portal [2]Coord) bool {
+	for i := range m.links[from] {
+		link := &m.links[from][i]
+		if link.to != to {
+			continue
+		}
+		merged, ok := mergePortal(link.portal, portal)
+		if !ok {
+			continue
+		}
+		for j := range m.links[to] {
+			if back := &m.links[to][j]; back.to == from && back.portal == link.portal {
+				back.portal = merged
+				back.wt = CalMidCoord(merged[0], merged[1])
+			}
+		}
+		link.portal = merged
+		link.wt = CalMidCoord(merged[0], merged[1])
+		return true
+	}
+	return false
+}
+
+// mergePortal merges two collinear portals sharing an endpoint into the portal spanning both
+func mergePortal(a, b [2]Coord) ([2]Coord, bool) {
+	if a[0] != b[0] && a[0] != b[1] && a[1] != b[0] && a[1] != b[1] {
+		return a, false
+	}
+	if cross(a[1], b[0], a[0]) != 0 || cross(a[1], b[1], a[0]) != 0 {
+		return a, false
+	}
+	return farthestCoords([]Coord{a[0], a[1], b[0], b[1]}), true
+}
+
+// farthestCoords returns the two coordinates farthest apart
+func farthestCoords(coords []Coord) [2]Coord {
+	var ret [2]Coord
+	best := -1.
+	for i := range coords {
+		for j := i + 1; j < len(coords); j++ {
+			if dst := CalDstCoordToCoordWithoutSqrt(coords[i], coords[j]); dst > best {
+				best = dst
+				ret = [2]Coord{coords[i], coords[j]}
+			}
+		}
+	}
+	return ret
+}
+
+// linkByVertices links polygons sharing two vertex indices
+func (m *NavMesh) linkByVertices() {
+	byVertice := make(map[int32][]int)
+	for slot, poly := range m.Polygons {
+		for _, v := range poly.GetVertices() {
+			byVertice[v.Index] = append(byVertice[v.Index], slot)
+		}
+	}
+
+	linked := make(map[[2]int]bool)
+	for slot, poly := range m.Polygons {
+		candidates := make(map[int]bool)
+		for _, v := range poly.GetVertices() {
+			for _, other := range byVertice[v.Index] {
+				if other > slot {
+					candidates[other] = true
+				}
+			}
+		}
+		others := make([]int, 0, len(candidates))
+		for other := range candidates {
+			others = append(others, other)
+		}
+		sort.Ints(others)
+
+		for _, other := range others {
+			if linked[[2]int{slot, other}] {
+				continue
+			}
+			portal, ok := neighborPortal(poly, m.Polygons[other])
+			if !ok {
+				continue
+			}
+			linked[[2]int{slot, other}] = true
+			wt := CalMidCoord(portal[0], portal[1])
+			m.links[slot] = append(m.links[slot], navLink{to: other, portal: portal, wt: wt})
+			m.links[other] = append(m.links[other], navLink{to: slot, portal: portal, wt: wt})
+		}
+	}
+}
+
+// neighborPortal returns the shared edge of two polygons using Convex.GetNeighborPoints
+// Several shared vertices form one portal when they are collinear
+func neighborPortal(a, b Polygon) ([2]Coord, bool) {
+	c, ok := a.(*Convex)
+	if !ok {
+		c = &Convex{Vertices: a.GetVertices()}
+	}
+	if points := c.GetNeighborPoints(b); len(points) >= 2 {
+		return [2]Coord{points[0].Coord, points[1].Coord}, true
+	}
+
+	indices := make(map[int32]bool, len(c.Vertices))
+	for _, v := range c.Vertices {
+		indices[v.Index] = true
+	}
+	var shared []Coord
+	for _, v := range b.GetVertices() {
+		if indices[v.Index] {
+			shared = append(shared, v.Coord)
+		}
+	}
+	if len(shared) < 3 {
+		return [2]Coord{}, false
+	}
+	for _, p := range shared[2:] {
+		if cross(shared[0], shared[1], p) != 0 {
+			return [2]Coord{}, false
+		}
+	}
+	return farthestCoords(shared), true
+}
+
+// calVerticesCenter returns the arithmetic mean of vertices
+func calVerticesCenter(vertices []Vertice) Coord {
+	if len(vertices) == 0 {
+		return Coord{}
+	}
+	var x, z int64
+	for _, v := range vertices {
+		x += int64(v.Coord.X)
+		z += int64(v.Coord.Z)
+	}
+	n := int64(len(vertices))
+	return Coord{X: int32(x / n), Z: int32(z / n)}
+}
+
+// GetPolygon returns the polygon with the given index
+func (m *NavMesh) GetPolygon(index int32) (Polygon, bool) {
+	slot, ok := m.slots[index]
+	if !ok {
+		return nil, false
+	}
+	return m.Polygons[slot], true
+}
+
+// GetNeighbors returns the indices of polygons connected to the given polygon
+func (m *NavMesh) GetNeighbors(index int32) []int32 {
+	slot, ok := m.slots[index]
+	if !ok {
+		return nil
+	}
+	ret := make([]int32, 0, len(m.links[slot]))
+	for _, link := range m.links[slot] {
+		ret = append(ret, m.Polygons[link.to].GetIndex())
+	}
+	return ret
+}
+
+// FindPolygon returns the polygon that contains the given point
+func (m *NavMesh) FindPolygon(p Coord) (Polygon, bool) {
+	slot := m.locate(p, nil)
+	if slot < 0 {
+		return nil, false
+	}
+	return m.Polygons[slot], true
+}
+
+// locate returns the lowest slot of the polygons containing p and passing the filter, or -1
+func (m *NavMesh) locate(p Coord, filter *QueryFilter) int {
+	return m.tree.locate(p, filter.PassPolygon)
+}
+
+// FindPolyPath searches the polygon corridor from start to end with A*
+// filter: polygons the corridor may enter and their costs, nil accepts every polygon at cost 1
+// Returns polygon indices from the start polygon to the end polygon
+func (m *NavMesh) FindPolyPath(start, end Coord, filter *QueryFilter) ([]int32, error) {
+	slots, err := m.findCorridor(start, end, filter)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]int32, len(slots))
+	for i, slot := range slots {
+		ret[i] = m.Polygons[slot].GetIndex()
+	}
+	return ret, nil
+}
+
+// FindPath searches a path from start to end
+// The polygon corridor is found with A* and then string-pulled with the simple stupid funnel algorithm
+// filter: polygons the path may enter and their costs, nil accepts every polygon at cost 1
+// Returns the path corners including start and end
+func (m *NavMesh) FindPath(start, end Coord, filter *QueryFilter) ([]Coord, error) {
+	slots, err := m.findCorridor(start, end, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	portals := make([][2]Coord, 0, len(slots)+1)
+	portals = append(portals, [2]Coord{start, start})
+	for i := 0; i < len(slots)-1; i++ {
+		link := m.findLink(slots[i], slots[i+1])
+		portal := m.orientPortal(slots[i], link.portal)
+		// Portals holding the start or the end point are crossed on the spot, the funnel
+		// would otherwise open flat around the apex and turn at a wrong corner
+		if len(portals) == 1 && isCoordOnPortal(start, portal) {
+			continue
+		}
+		portals = append(portals, portal)
+	}
+	for len(portals) > 1 && isCoordOnPortal(end, portals[len(portals)-1]) {
+		portals = portals[:len(portals)-1]
+	}
+	portals = append(portals, [2]Coord{end, end})
+
+	return stringPull(portals), nil
+}
+
+// findCorridor runs A* over polygon adjacency and returns polygon slots
+// The distance walked inside a polygon is multiplied by its area cost.
+func (m *NavMesh) findCorridor(start, end Coord, filter *QueryFilter) ([]int, error) {
+	startSlot := m.locate(start, filter)
+	endSlot := m.locate(end, filter)
+	if startSlot < 0 || endSlot < 0 {
+		return nil, ErrCoordNotOnMesh
+	}
+	if startSlot == endSlot {
+		return []int{startSlot}, nil
+	}
+
+	n := len(m.Polygons)
+	cost := make([]float64, n)
+	parent := make([]int, n)
+	pos := make([]Coord, n)
+	closed := make([]bool, n)
+	for i := range cost {
+		cost[i] = math.MaxFloat64
+		parent[i] = -1
+	}
+	cost[startSlot] = 0
+	pos[startSlot] = start
+
+	// The heuristic assumes the cheapest area all the way to stay admissible
+	scale := filter.minCost()
+	open := &navNodeHeap{}
+	heap.Push(open, navNode{slot: startSlot, total: CalDstCoordToCoord(start, end) * scale})
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(navNode)
+		if closed[cur.slot] {
+			continue
+		}
+		closed[cur.slot] = true
+		if cur.slot == endSlot {
+			break
+		}
+
+		for _, link := range m.links[cur.slot] {
+			if closed[link.to] || !filter.PassPolygon(m.Polygons[link.to]) {
+				continue
+			}
+			g := cost[cur.slot] + CalDstCoordToCoord(pos[cur.slot], link.wt)*filter.AreaCost(m.Polygons[cur.slot])
+			// The last step also pays for reaching the end point
+			if link.to == endSlot {
+				g += CalDstCoordToCoord(link.wt, end) * filter.AreaCost(m.Polygons[endSlot])
+			}
+			if g >= cost[link.to] {
+				continue
+			}
+			cost[link.to] = g
+			parent[link.to] = cur.slot
+			pos[link.to] = link.wt
+			heap.Push(open, navNode{slot: link.to, total: g + CalDstCoordToCoord(link.wt, end)*scale})
+		}
+	}
+
+	if parent[endSlot] < 0 {
+		return nil, ErrPathNotFound
+	}
+	var corridor []int
+	for slot := endSlot; slot >= 0; slot = parent[slot] {
+		corridor = append(corridor, slot)
+	}
+	for i, j := 0, len(corridor)-1; i < j; i, j = i+1, j-1 {
+		corridor[i], corridor[j] = corridor[j], corridor[i]
+	}
+	return corridor, nil
+}
+
+// findLink returns the cheapest portal from one slot to another
+func (m *NavMesh) findLink(from, to int) navLink {
+	var ret navLink
+	best := -1.
+	for _, link := range m.links[from] {
+		if link.to != to {
+			continue
+		}
+		width := CalDstCoordToCoord(link.portal[0], link.portal[1])
+		if width > best {
+			best = width
+			ret = link
+		}
+	}
+	return ret
+}
+
+// orientPortal returns portal endpoints as [left, right] seen from inside the polygon
+func (m *NavMesh) orientPortal(from int, portal [2]Coord) [2]Coord {
+	// Left endpoint is counter-clockwise of the right endpoint around the polygon center
+	if cross(portal[0], portal[1], m.centers[from]) > 0 {
+		return [2]Coord{portal[1], portal[0]}
+	}
+	return portal
+}
+
+// isCoordOnPortal checks if the point lies on the portal, endpoints included
+func isCoordOnPortal(p Coord, portal [2]Coord) bool {
+	seg := NewSegment(portal[0], portal[1])
+	return seg.IsCoordInside(p)
+}
+
+// stringPull finds the shortest path through portals with the simple stupid funnel algorithm
+// portals: [left, right] pairs, the first is the start point and the last is the end point
+// Reference: http://digestingduck.blogspot.com/2010/03/simple-stupid-funnel-algorithm.html
+func stringPull(portals [][2]Coord) []Coord {
+	apex := portals[0][0]
+	left, right := portals[0][0], portals[0][1]
+	apexIndex, leftIndex, rightIndex := 0, 0, 0
+	path := []Coord{apex}
+
+	for i := 1; i < len(portals); i++ {
+		l, r := portals[i][0], portals[i][1]
+
+		// Update right vertex
+		if cross(right, r, apex) >= 0 {
+			if apex == right || cross(left, r, apex) < 0 {
+				// Tighten the funnel
+				right = r
+				rightIndex = i
+			} else {
+				// Right over left, left becomes the new apex
+				path = appendCorner(path, left)
+				apex = left
+				apexIndex = leftIndex
+				right = apex
+				rightIndex = apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+
+		// Update left vertex
+		if cross(left, l, apex) <= 0 {
+			if apex == left || cross(right, l, apex) > 0 {
+				// Tighten the funnel
+				left = l
+				leftIndex = i
+			} else {
+				// Left over right, right becomes the new apex
+				path = appendCorner(path, right)
+				apex = right
+				apexIndex = rightIndex
+				left = apex
+				leftIndex = apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+	}
+
+	return appendCorner(path, portals[len(portals)-1][0])
+}
+
+// appendCorner appends a corner to the path unless the path already ends there
+// The funnel restarts from an apex shared by several portals, which may turn at the same corner again.
+func appendCorner(path []Coord, corner Coord) []Coord {
+	if path[len(path)-1] == corner {
+		return path
+	}
+	return append(path, corner)
+}
+
+// navNode represents an open node in the A* search
+type navNode struct {
+	slot  int     // Polygon slot
+	total float64 // Estimated total cost
+}
+
+// navNodeHeap is a min-heap of A* nodes
+type navNodeHeap []navNode
+
+func (h navNodeHeap) Len() int           { return len(h) }
+func (h navNodeHeap) Less(i, j int) bool { return h[i].total < h[j].total }
+func (h navNodeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *navNodeHeap) Push(x any) {
+	*h = append(*h, x.(navNode))
+}
+
+func (h *navNodeHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
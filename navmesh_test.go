@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// newSquareMesh builds a navmesh of 100x100 squares with their bottom-left corners at cells, two triangles each
+func newSquareMesh(t *testing.T, cells ...Coord) *NavMesh {
+	t.Helper()
+	var coords []Coord
+	var indices [][3]int32
+	for _, c := range cells {
+		n := int32(len(coords))
+		coords = append(coords, c, Coord{X: c.X + 100, Z: c.Z}, Coord{X: c.X + 100, Z: c.Z + 100}, Coord{X: c.X, Z: c.Z + 100})
+		indices = append(indices, [3]int32{n, n + 1, n + 2}, [3]int32{n, n + 2, n + 3})
+	}
+	b := NewMeshBuilder(0)
+	if err := b.AddIndexed(coords, indices); err != nil {
+		t.Fatal(err)
+	}
+	triangles, edges, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	polygons := make([]Polygon, len(triangles))
+	for i, tri := range triangles {
+		polygons[i] = tri
+	}
+	return NewNavMesh(polygons, edges)
+}
+
+func TestNavMeshFindPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		cells      []Coord
+		start, end Coord
+		want       []Coord
+	}{
+		{
+			name:  "straight corridor",
+			cells: []Coord{{X: 0, Z: 0}, {X: 100, Z: 0}, {X: 200, Z: 0}},
+			start: Coord{X: 10, Z: 50},
+			end:   Coord{X: 290, Z: 50},
+			want:  []Coord{{X: 10, Z: 50}, {X: 290, Z: 50}},
+		},
+		{
+			name:  "corner",
+			cells: []Coord{{X: 0, Z: 0}, {X: 100, Z: 0}, {X: 100, Z: 100}},
+			start: Coord{X: 50, Z: 50},
+			end:   Coord{X: 150, Z: 180},
+			want:  []Coord{{X: 50, Z: 50}, {X: 100, Z: 100}, {X: 150, Z: 180}},
+		},
+		{
+			name:  "same polygon",
+			cells: []Coord{{X: 0, Z: 0}},
+			start: Coord{X: 10, Z: 10},
+			end:   Coord{X: 90, Z: 20},
+			want:  []Coord{{X: 10, Z: 10}, {X: 90, Z: 20}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newSquareMesh(t, tt.cells...)
+			got, err := m.FindPath(tt.start, tt.end, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FindPath() = %v, want %v", got, tt.want)
+			}
+
+			corridor, err := m.FindPolyPath(tt.start, tt.end, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			first, _ := m.FindPolygon(tt.start)
+			last, _ := m.FindPolygon(tt.end)
+			if corridor[0] != first.GetIndex() || corridor[len(corridor)-1] != last.GetIndex() {
+				t.Errorf("FindPolyPath() = %v, want from %d to %d", corridor, first.GetIndex(), last.GetIndex())
+			}
+		})
+	}
+}
+
+func TestNavMeshFindPathBlocked(t *testing.T) {
+	// Two squares with a gap between them
+	m := newSquareMesh(t, Coord{X: 0, Z: 0}, Coord{X: 200, Z: 0})
+	if _, err := m.FindPath(Coord{X: 50, Z: 50}, Coord{X: 250, Z: 50}, nil); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("FindPath() across the gap error = %v, want %v", err, ErrPathNotFound)
+	}
+	if _, err := m.FindPath(Coord{X: 50, Z: 50}, Coord{X: 150, Z: 50}, nil); !errors.Is(err, ErrCoordNotOnMesh) {
+		t.Errorf("FindPath() into the gap error = %v, want %v", err, ErrCoordNotOnMesh)
+	}
+}
@@ -0,0 +1,207 @@
+package geo
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// NavRaycastHit represents the result of walking a straight line over the navmesh
+type NavRaycastHit struct {
+	Reached  bool    // Whether the line stays on the mesh up to the target
+	T        float64 // Fraction of the line on the mesh, 1 when reached
+	Coord    Coord   // Last valid point of the line, the target when reached
+	Wall     Segment // Boundary edge or edge into a filtered out polygon blocking the line, zero when reached
+	Edge     *Edge   // Mesh edge of the wall, nil when reached or when the mesh was built without edges
+	Polygons []int32 // Indices of the polygons crossed by the line, in order
+}
+
+// Raycast walks the straight line from start toward end across the shared edges of the polygons
+// The walk stops at the first boundary edge, which is returned with the last valid point.
+// filter: polygons the line may enter, edges into the other polygons are walls, nil accepts every polygon
+// Returns ErrCoordNotOnMesh when start is not inside any polygon passing the filter,
+// and ErrInconsistentMesh when the line comes back to a polygon it already crossed.
+// Reference: https://github.com/recastnavigation/recastnavigation/blob/main/Detour/Source/DetourNavMeshQuery.cpp
+func (m *NavMesh) Raycast(start, end Coord, filter *QueryFilter) (NavRaycastHit, error) {
+	slot := m.locate(start, filter)
+	if slot < 0 {
+		return NavRaycastHit{}, ErrCoordNotOnMesh
+	}
+	a, b := coordPoint(start), coordPoint(end)
+	hit := NavRaycastHit{Coord: start}
+	visited := make(map[int]bool)
+	for {
+		if visited[slot] {
+			return NavRaycastHit{}, fmt.Errorf("%w: polygon %d crossed twice", ErrInconsistentMesh, m.Polygons[slot].GetIndex())
+		}
+		visited[slot] = true
+		hit.Polygons = append(hit.Polygons, m.Polygons[slot].GetIndex())
+
+		vertices := ccwVertices(m.Polygons[slot].GetVertices())
+		t, exits := segmentExit(vertices, a, b)
+		if t >= 1 {
+			hit.Reached, hit.T, hit.Coord = true, 1, end
+			return hit, nil
+		}
+		hit.T = max(hit.T, t)
+		p := satPoint{x: a.x + hit.T*(b.x-a.x), z: a.z + hit.T*(b.z-a.z)}
+		hit.Coord = satCoord(p)
+		// Collinear edges leave at the same fraction, the ones holding the exit point come first
+		slices.SortStableFunc(exits, func(i, j int) int {
+			return cmp.Compare(edgeDst(vertices, i, p), edgeDst(vertices, j, p))
+		})
+
+		next := -1
+		for _, i := range exits {
+			to := m.crossEdge(slot, vertices[i].Coord, vertices[(i+1)%len(vertices)].Coord, p)
+			if to >= 0 && filter.PassPolygon(m.Polygons[to]) {
+				next = to
+				break
+			}
+		}
+		if next < 0 {
+			v1, v2 := vertices[exits[0]], vertices[(exits[0]+1)%len(vertices)]
+			hit.Wall = NewSegment(v1.Coord, v2.Coord)
+			hit.Edge = m.edgeKeys[GenEdgeKey64(v1.Index, v2.Index)]
+			return hit, nil
+		}
+		slot = next
+	}
+}
+
+// FindNearestCoord snaps p to the nearest walkable point of the mesh within extent
+// filter: polygons the point may be snapped to, nil accepts every polygon
+// Returns the point, p itself when it is on the mesh, with the index of the polygon holding it.
+// Returns ErrCoordNotOnMesh when no polygon passing the filter is within extent.
+func (m *NavMesh) FindNearestCoord(p Coord, extent int32, filter *QueryFilter) (Coord, int32, error) {
+	slot := -1
+	m.tree.nearest(p, func(index int, dst float64) bool {
+		if dst > float64(extent) {
+			return false
+		}
+		if !filter.PassPolygon(m.Polygons[index]) {
+			return true
+		}
+		slot = index
+		return false
+	})
+	if slot < 0 {
+		return Coord{}, 0, ErrCoordNotOnMesh
+	}
+	poly := m.Polygons[slot]
+	return closestPolygonCoord(poly, p), poly.GetIndex(), nil
+}
+
+// RandCoord returns a uniformly distributed random walkable point with the index of its polygon
+// r: source of randomness, the global source of math/rand is used when nil
+func (m *NavMesh) RandCoord(r *rand.Rand) (Coord, int32, error) {
+	p, poly, ok := m.sampler.RandCoord(r)
+	if !ok {
+		return Coord{}, 0, ErrNoWalkableCoord
+	}
+	return p, poly.GetIndex(), nil
+}
+
+// RandCoordInCircle returns a uniformly distributed random walkable point within radius of center
+// with the index of its polygon, for spawning and wandering around a point.
+// r: source of randomness, the global source of math/rand is used when nil
+func (m *NavMesh) RandCoordInCircle(r *rand.Rand, center Coord, radius int32) (Coord, int32, error) {
+	p, poly, ok := m.sampler.RandCoordInCircle(r, NewCirCle(center, radius))
+	if !ok {
+		return Coord{}, 0, ErrNoWalkableCoord
+	}
+	return p, poly.GetIndex(), nil
+}
+
+// closestPolygonCoord returns the point of the polygon closest to p
+// The closest point on the boundary is rounded to a lattice point inside the polygon when one is next to it.
+func closestPolygonCoord(poly Polygon, p Coord) Coord {
+	if poly.IsCoordInside(p) {
+		return p
+	}
+	x := coordPoint(p)
+	best, bestDst := x, math.MaxFloat64
+	for _, ring := range polygonRings(poly) {
+		for i := range ring {
+			c := closestOnSegment(coordPoint(ring[i]), coordPoint(ring[(i+1)%len(ring)]), x)
+			if dst := (c.x-x.x)*(c.x-x.x) + (c.z-x.z)*(c.z-x.z); dst < bestDst {
+				best, bestDst = c, dst
+			}
+		}
+	}
+
+	ret := satCoord(best)
+	found := false
+	minX, minZ := int32(math.Floor(best.x)), int32(math.Floor(best.z))
+	for _, c := range [4]Coord{{X: minX, Z: minZ}, {X: minX + 1, Z: minZ}, {X: minX, Z: minZ + 1}, {X: minX + 1, Z: minZ + 1}} {
+		if !poly.IsCoordInside(c) {
+			continue
+		}
+		if !found || CalDstCoordToCoordWithoutSqrt(p, c) < CalDstCoordToCoordWithoutSqrt(p, ret) {
+			ret, found = c, true
+		}
+	}
+	return ret
+}
+
+// segmentExit returns the fraction of segment a, b where it leaves the convex polygon
+// with the edges it leaves through, several edges when it leaves through a vertex.
+// The fraction is at least 1 when b is inside the polygon.
+func segmentExit(vertices []Vertice, a, b satPoint) (float64, []int) {
+	points := make([]satPoint, len(vertices))
+	for i, v := range vertices {
+		points[i] = coordPoint(v.Coord)
+	}
+	exit := 1.
+	var edges []int
+	for i := range points {
+		n := edgeNormal(points, i)
+		den := n.x*(b.x-a.x) + n.z*(b.z-a.z)
+		if den <= 0 {
+			continue
+		}
+		t := (n.x*(points[i].x-a.x) + n.z*(points[i].z-a.z)) / den
+		switch {
+		case t < exit-1e-9:
+			exit, edges = t, append(edges[:0], i)
+		case t <= exit+1e-9 && t < 1:
+			edges = append(edges, i)
+		}
+	}
+	if len(edges) == 0 {
+		return 1, nil
+	}
+	return exit, edges
+}
+
+// edgeDst returns the squared distance from x to edge i of the polygon
+func edgeDst(vertices []Vertice, i int, x satPoint) float64 {
+	c := closestOnSegment(coordPoint(vertices[i].Coord), coordPoint(vertices[(i+1)%len(vertices)].Coord), x)
+	dx, dz := c.x-x.x, c.z-x.z
+	return dx*dx + dz*dz
+}
+
+// crossEdge returns the slot of the polygon entered through edge p, q of the polygon at x, or -1
+func (m *NavMesh) crossEdge(slot int, p, q Coord, x satPoint) int {
+	for _, link := range m.links[slot] {
+		span := link.span()
+		if cross(q, span[0], p) != 0 || cross(q, span[1], p) != 0 {
+			continue
+		}
+		c := closestOnSegment(coordPoint(span[0]), coordPoint(span[1]), x)
+		if dx, dz := c.x-x.x, c.z-x.z; dx*dx+dz*dz <= 1e-12 {
+			return link.to
+		}
+	}
+	return -1
+}
+
+// span returns the whole shared edge of the link, portals narrowed by inflection points are widened back
+func (l *navLink) span() [2]Coord {
+	if l.edge != nil && l.edge.hasInflects() {
+		return [2]Coord{l.edge.Vertices[0].Coord, l.edge.Vertices[1].Coord}
+	}
+	return l.portal
+}
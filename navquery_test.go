@@ -0,0 +1,140 @@
+package geo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNavMeshRaycast(t *testing.T) {
+	// 2x2 squares, triangles 0-1, 2-3, 4-5 and 6-7 split along their rising diagonal
+	m := newSquareMesh(t, Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}, Coord{X: 0, Z: 100}, Coord{X: 100, Z: 100})
+	tests := []struct {
+		name       string
+		start, end Coord
+		reached    bool
+		coord      Coord
+		wall       Segment
+		polygons   []int32
+	}{
+		{
+			name:  "through a vertex",
+			start: Coord{X: 20, Z: 80}, end: Coord{X: 180, Z: 120},
+			reached: true, coord: Coord{X: 180, Z: 120},
+			polygons: []int32{1, 4, 7, 6},
+		},
+		{
+			name:  "along collinear diagonals",
+			start: Coord{X: 10, Z: 10}, end: Coord{X: 190, Z: 190},
+			reached: true, coord: Coord{X: 190, Z: 190},
+			polygons: []int32{0, 3, 6},
+		},
+		{
+			name:  "along the boundary",
+			start: Coord{X: 0, Z: 50}, end: Coord{X: 0, Z: 150},
+			reached: true, coord: Coord{X: 0, Z: 150},
+			polygons: []int32{1, 4, 5},
+		},
+		{
+			name:  "from an edge",
+			start: Coord{X: 100, Z: 50}, end: Coord{X: 150, Z: 50},
+			reached: true, coord: Coord{X: 150, Z: 50},
+			polygons: []int32{0, 3},
+		},
+		{
+			name:  "from an edge into a wall",
+			start: Coord{X: 100, Z: 50}, end: Coord{X: -50, Z: 50},
+			coord: Coord{X: 0, Z: 50}, wall: NewSegment(Coord{X: 0, Z: 100}, Coord{X: 0, Z: 0}),
+			polygons: []int32{0, 1},
+		},
+		{
+			name:  "through a vertex into a wall",
+			start: Coord{X: 50, Z: 50}, end: Coord{X: 250, Z: 250},
+			coord: Coord{X: 200, Z: 200}, wall: NewSegment(Coord{X: 200, Z: 100}, Coord{X: 200, Z: 200}),
+			polygons: []int32{0, 3, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, err := m.Raycast(tt.start, tt.end, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hit.Reached != tt.reached || hit.Coord != tt.coord || hit.Wall != tt.wall || !slices.Equal(hit.Polygons, tt.polygons) {
+				t.Errorf("Raycast() = %+v, want reached %v at %v, wall %v through %v", hit, tt.reached, tt.coord, tt.wall, tt.polygons)
+			}
+			if hit.Reached != (hit.Edge == nil) {
+				t.Errorf("Raycast() edge = %v, want an edge only for walls", hit.Edge)
+			}
+			if e := hit.Edge; e != nil && NewSegment(e.Vertices[0].Coord, e.Vertices[1].Coord) != hit.Wall &&
+				NewSegment(e.Vertices[1].Coord, e.Vertices[0].Coord) != hit.Wall {
+				t.Errorf("Raycast() edge %v does not match wall %v", hit.Edge.Vertices, hit.Wall)
+			}
+		})
+	}
+
+	if _, err := m.Raycast(Coord{X: 300, Z: 300}, Coord{X: 0, Z: 0}, nil); !errors.Is(err, ErrCoordNotOnMesh) {
+		t.Errorf("Raycast() off the mesh error = %v, want %v", err, ErrCoordNotOnMesh)
+	}
+}
+
+func TestNavMeshRaycastInconsistentMesh(t *testing.T) {
+	// Two copies of the same triangle linked across their hypotenuse send the line back and forth
+	vertices := []Vertice{{Index: 0, Coord: Coord{X: 0, Z: 0}}, {Index: 1, Coord: Coord{X: 0, Z: 100}}, {Index: 2, Coord: Coord{X: 100, Z: 0}}}
+	a := &Triangle{Index: 0, Vertices: vertices}
+	b := &Triangle{Index: 1, Vertices: vertices}
+	edges := []Edge{{
+		Vertices:           [2]Vertice{vertices[1], vertices[2]},
+		AdjacenctTriangles: []*Triangle{a, b},
+		IsAdjacency:        true,
+	}}
+	m := NewNavMesh([]Polygon{a, b}, edges)
+	if _, err := m.Raycast(Coord{X: 10, Z: 10}, Coord{X: 200, Z: 200}, nil); !errors.Is(err, ErrInconsistentMesh) {
+		t.Errorf("Raycast() error = %v, want %v", err, ErrInconsistentMesh)
+	}
+}
+
+func TestNavMeshFindNearestCoord(t *testing.T) {
+	squares := newSquareMesh(t, Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0})
+
+	// A single triangle with a sloped hypotenuse x + z = 100
+	b := NewMeshBuilder(0)
+	if err := b.AddIndexed([]Coord{{X: 0, Z: 0}, {X: 100, Z: 0}, {X: 0, Z: 100}}, [][3]int32{{0, 1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	triangles, edges, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	slope := NewNavMesh([]Polygon{triangles[0]}, edges)
+
+	tests := []struct {
+		name    string
+		m       *NavMesh
+		p       Coord
+		extent  int32
+		want    Coord
+		wantPol int32
+		wantErr error
+	}{
+		{name: "on the mesh", m: squares, p: Coord{X: 30, Z: 20}, extent: 10, want: Coord{X: 30, Z: 20}, wantPol: 0},
+		{name: "other triangle", m: squares, p: Coord{X: 20, Z: 70}, extent: 10, want: Coord{X: 20, Z: 70}, wantPol: 1},
+		{name: "below the mesh", m: squares, p: Coord{X: 50, Z: -30}, extent: 50, want: Coord{X: 50, Z: 0}, wantPol: 0},
+		{name: "past the end", m: squares, p: Coord{X: 230, Z: 50}, extent: 50, want: Coord{X: 200, Z: 50}, wantPol: 2},
+		{name: "at the extent", m: squares, p: Coord{X: 230, Z: -40}, extent: 50, want: Coord{X: 200, Z: 0}, wantPol: 2},
+		{name: "past the extent", m: squares, p: Coord{X: 230, Z: -40}, extent: 49, wantErr: ErrCoordNotOnMesh},
+		{name: "sloped edge", m: slope, p: Coord{X: 60, Z: 61}, extent: 50, want: Coord{X: 50, Z: 50}, wantPol: 0},
+		{name: "sloped edge rounded inside", m: slope, p: Coord{X: 61, Z: 60}, extent: 50, want: Coord{X: 51, Z: 49}, wantPol: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, poly, err := tt.m.FindNearestCoord(tt.p, tt.extent, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindNearestCoord() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (got != tt.want || poly != tt.wantPol) {
+				t.Errorf("FindNearestCoord() = %v, %d, want %v, %d", got, poly, tt.want, tt.wantPol)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package geo
+
+import (
+	"math"
+)
+
+// OBB represents an oriented bounding box (rotated rectangle)
+type OBB struct {
+	Center     Coord   // Center coordinate of the box
+	HalfWidth  int32   // Half extent along the local X axis
+	HalfHeight int32   // Half extent along the local Z axis
+	Angle      float64 // Rotation of the local axes in radians, counter-clockwise
+}
+
+// NewOBB creates a new oriented bounding box with given center, half extents and rotation
+func NewOBB(center Coord, halfWidth, halfHeight int32, angle float64) OBB {
+	return OBB{
+		Center:     center,
+		HalfWidth:  halfWidth,
+		HalfHeight: halfHeight,
+		Angle:      angle,
+	}
+}
+
+// GetCoords returns the 4 vertex coordinates in counter-clockwise order
+// Corners are rounded to the nearest coordinate, so the polygon matches IsCoordInside up to that rounding.
+func (o *OBB) GetCoords() []Coord {
+	w, h := float64(o.HalfWidth), float64(o.HalfHeight)
+	corners := [4]satPoint{{x: -w, z: -h}, {x: w, z: -h}, {x: w, z: h}, {x: -w, z: h}}
+	cos, sin := math.Cos(o.Angle), math.Sin(o.Angle)
+	center := coordPoint(o.Center)
+	coords := make([]Coord, len(corners))
+	for i, c := range corners {
+		coords[i] = satCoord(satPoint{x: center.x + c.x*cos - c.z*sin, z: center.z + c.x*sin + c.z*cos})
+	}
+	return coords
+}
+
+// convexPolygon marks the oriented bounding box as a ConvexPolygon
+func (o *OBB) convexPolygon() {}
+
+// GetVectors returns vector array of the box in counter-clockwise order
+func (o *OBB) GetVectors() []Vector {
+	coords := o.GetCoords()
+	vecs := make([]Vector, 0, len(coords))
+	for _, p := range coords {
+		vecs = append(vecs, NewVectorByCoord(p))
+	}
+	return vecs
+}
+
+// toLocal returns the point in the local frame of the box
+func (o *OBB) toLocal(p Coord) (float64, float64) {
+	dx, dz := float64(p.X-o.Center.X), float64(p.Z-o.Center.Z)
+	cos, sin := math.Cos(o.Angle), math.Sin(o.Angle)
+	return dx*cos + dz*sin, -dx*sin + dz*cos
+}
+
+// IsCoordInside checks if point is inside the box, points on the edges are inside
+func (o *OBB) IsCoordInside(p Coord) bool {
+	x, z := o.toLocal(p)
+	return math.Abs(x) <= float64(o.HalfWidth) && math.Abs(z) <= float64(o.HalfHeight)
+}
+
+// IsInterCircle checks if the box intersects with circle
+func (o *OBB) IsInterCircle(c *Circle) bool {
+	x, z := o.toLocal(c.Center)
+	// Distance from the center of the circle to the closest point of the box in the local frame
+	dx := math.Max(math.Abs(x)-float64(o.HalfWidth), 0)
+	dz := math.Max(math.Abs(z)-float64(o.HalfHeight), 0)
+	r := float64(c.Radius)
+	return dx*dx+dz*dz <= r*r
+}
+
+// IsInterOBB checks if two boxes intersect with the Separating Axis Theorem
+func (o *OBB) IsInterOBB(b *OBB) bool {
+	_, ok := collideCoords(o.GetCoords(), b.GetCoords())
+	return ok
+}
+
+// IsInterConvex checks if the box intersects with a convex polygon with the Separating Axis Theorem
+func (o *OBB) IsInterConvex(p ConvexPolygon) bool {
+	_, ok := collideCoords(o.GetCoords(), p.GetCoords())
+	return ok
+}
+
+// Support returns the box vertex farthest in the direction
+func (o *OBB) Support(dir Vector) Coord {
+	return supportCoords(o.GetCoords(), dir)
+}
+
+// ToConvex converts the box to a convex polygon made of two clockwise triangles
+func (o *OBB) ToConvex(id int32) *Convex {
+	coords := o.GetCoords()
+	vertices := make([]Vertice, len(coords))
+	for i, p := range coords {
+		vertices[i] = Vertice{Index: int32(i), Coord: p}
+	}
+	return &Convex{
+		Index:    id,
+		Vertices: vertices,
+		MergeTriangles: []*Triangle{
+			{Index: 0, Vertices: []Vertice{vertices[0], vertices[2], vertices[1]}},
+			{Index: 1, Vertices: []Vertice{vertices[0], vertices[3], vertices[2]}},
+		},
+		WtCoord: o.Center,
+	}
+}
+
+// ToRect returns the axis-aligned bounding rectangle of the box
+// Returns the minimum and maximum X,Z coordinates of the bounding rectangle
+func (o *OBB) ToRect() (minX, minZ, maxX, maxZ int32) {
+	minX = int32(math.MaxInt32)
+	minZ = int32(math.MaxInt32)
+	maxX = int32(math.MinInt32)
+	maxZ = int32(math.MinInt32)
+	for _, p := range o.GetCoords() {
+		minX = min(p.X, minX)
+		minZ = min(p.Z, minZ)
+		maxX = max(p.X, maxX)
+		maxZ = max(p.Z, maxZ)
+	}
+	return minX, minZ, maxX, maxZ
+}
+
+// GetLocationToBorder returns the positional relationship between box and given border
+func (o *OBB) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := o.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
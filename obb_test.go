@@ -0,0 +1,60 @@
+package geo
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestOBBGetCoords(t *testing.T) {
+	tests := []struct {
+		name string
+		obb  OBB
+		want []Coord
+	}{
+		{
+			name: "axis aligned",
+			obb:  NewOBB(Coord{X: 10, Z: 20}, 100, 50, 0),
+			want: []Coord{{X: -90, Z: -30}, {X: 110, Z: -30}, {X: 110, Z: 70}, {X: -90, Z: 70}},
+		},
+		{
+			// Rotated corners such as (-61.6, -93.3) are rounded, not truncated
+			name: "30 degrees",
+			obb:  NewOBB(Coord{X: 0, Z: 0}, 100, 50, math.Pi/6),
+			want: []Coord{{X: -62, Z: -93}, {X: 112, Z: 7}, {X: 62, Z: 93}, {X: -112, Z: -7}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.obb.GetCoords(); !slices.Equal(got, tt.want) {
+				t.Errorf("GetCoords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOBBPolygonMatchesIsCoordInside(t *testing.T) {
+	o := NewOBB(Coord{X: 3, Z: -7}, 40, 25, 0.7)
+	c := o.ToConvex(0)
+	cos, sin := math.Cos(o.Angle), math.Sin(o.Angle)
+	for x := int32(-60); x <= 60; x++ {
+		for z := int32(-60); z <= 60; z++ {
+			p := Coord{X: x, Z: z}
+			// Distance of the point outside the box in the local frame, negative inside
+			dx, dz := float64(p.X-o.Center.X), float64(p.Z-o.Center.Z)
+			lx, lz := dx*cos+dz*sin, -dx*sin+dz*cos
+			outside := max(math.Abs(lx)-float64(o.HalfWidth), math.Abs(lz)-float64(o.HalfHeight))
+			// Rounded corners move the polygon edges by at most half a diagonal unit
+			if math.Abs(outside) <= math.Sqrt2/2 {
+				continue
+			}
+			if got, want := c.IsCoordInside(p), o.IsCoordInside(p); got != want {
+				t.Errorf("polygon of the box contains %v = %v, IsCoordInside = %v", p, got, want)
+			}
+			circle := NewCirCle(p, 0)
+			if got, want := Intersects(&circle, c), o.IsInterCircle(&circle); got != want {
+				t.Errorf("Intersects(%v, polygon) = %v, IsInterCircle = %v", p, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package geo
+
+// Bounded represents anything with a bounding rectangle
+type Bounded interface {
+	ToRect() (minX, minZ, maxX, maxZ int32) // Get bounding rectangle
+}
+
+// aabb represents an axis-aligned bounding box with inclusive bounds
+type aabb struct {
+	minX, minZ, maxX, maxZ int32
+}
+
+// newAABB returns the bounding box of an item
+func newAABB(b Bounded) aabb {
+	minX, minZ, maxX, maxZ := b.ToRect()
+	return aabb{minX: minX, minZ: minZ, maxX: maxX, maxZ: maxZ}
+}
+
+// rectAABB returns the bounding box of a rectangle
+func rectAABB(r Rectangle) aabb {
+	return aabb{minX: r.X, minZ: r.Z, maxX: r.X + r.Width, maxZ: r.Z + r.Height}
+}
+
+// circleAABB returns the bounding box of a circle
+func circleAABB(c Circle) aabb {
+	return newAABB(&c)
+}
+
+// overlaps checks if two boxes overlap, touching boxes overlap
+func (a aabb) overlaps(o aabb) bool {
+	return a.minX <= o.maxX && o.minX <= a.maxX && a.minZ <= o.maxZ && o.minZ <= a.maxZ
+}
+
+// containsAABB checks if the other box is fully inside the box
+func (a aabb) containsAABB(o aabb) bool {
+	return a.minX <= o.minX && o.maxX <= a.maxX && a.minZ <= o.minZ && o.maxZ <= a.maxZ
+}
+
+// contains checks if a point is inside the box
+func (a aabb) contains(p Coord) bool {
+	return a.minX <= p.X && p.X <= a.maxX && a.minZ <= p.Z && p.Z <= a.maxZ
+}
+
+// distSquared returns the squared distance from a point to the box, 0 when inside
+func (a aabb) distSquared(p Coord) float64 {
+	var dx, dz float64
+	if p.X < a.minX {
+		dx = float64(a.minX) - float64(p.X)
+	} else if p.X > a.maxX {
+		dx = float64(p.X) - float64(a.maxX)
+	}
+	if p.Z < a.minZ {
+		dz = float64(a.minZ) - float64(p.Z)
+	} else if p.Z > a.maxZ {
+		dz = float64(p.Z) - float64(a.maxZ)
+	}
+	return dx*dx + dz*dz
+}
+
+// quadrants lists the LocationState of the four children of a quadtree node
+var quadrants = [4]LocationState{LeftTop, RightTop, LeftBottom, RightBottom}
+
+// QuadTree represents a quadtree storing items by bounding rectangle
+// Nodes are split through Border quadrants, items crossing a center line stay in the parent node.
+type QuadTree[T interface {
+	comparable
+	Bounded
+}] struct {
+	MaxDepth int // Maximum depth of the tree, the root has depth 0
+	Capacity int // Number of items a node holds before it splits
+
+	root  *quadNode[T]
+	items map[T]*quadNode[T] // Node storing each item
+	rects map[T]aabb         // Bounding box of each item when it was stored
+}
+
+// quadNode represents a node of the quadtree
+type quadNode[T comparable] struct {
+	border   Border
+	depth    int
+	parent   *quadNode[T]
+	children [4]*quadNode[T] // Children in quadrants order, nil for leaves
+	items    []T             // Items stored in this node
+	count    int             // Number of items in this subtree
+}
+
+// NewQuadTree creates a quadtree covering the border
+// Items outside the border are stored in the root node
+func NewQuadTree[T interface {
+	comparable
+	Bounded
+}](border Border, maxDepth, capacity int) *QuadTree[T] {
+	return &QuadTree[T]{
+		MaxDepth: maxDepth,
+		Capacity: max(capacity, 1),
+		root:     &quadNode[T]{border: border},
+		items:    make(map[T]*quadNode[T]),
+		rects:    make(map[T]aabb),
+	}
+}
+
+// Len returns the number of items in the tree
+func (q *QuadTree[T]) Len() int {
+	return len(q.items)
+}
+
+// Insert adds an item to the tree, returns false if it already exists
+func (q *QuadTree[T]) Insert(item T) bool {
+	if _, exist := q.items[item]; exist {
+		return false
+	}
+	q.insert(q.root, item, newAABB(item))
+	return true
+}
+
+// insert stores an item in the deepest node fully containing it
+func (q *QuadTree[T]) insert(node *quadNode[T], item T, rect aabb) {
+	for {
+		node.count++
+		if node.children[0] == nil {
+			break
+		}
+		child := node.childOf(rect)
+		if child == nil {
+			break
+		}
+		node = child
+	}
+	node.items = append(node.items, item)
+	q.items[item] = node
+	q.rects[item] = rect
+
+	if node.children[0] == nil && len(node.items) > q.Capacity && node.depth < q.MaxDepth {
+		q.split(node)
+	}
+}
+
+// childOf returns the only child containing the rectangle, nil if it crosses a center line
+func (n *quadNode[T]) childOf(rect aabb) *quadNode[T] {
+	if !rectAABB(n.border.Rectangle).containsAABB(rect) {
+		return nil
+	}
+	location := n.border.RectLocation(rect.minX, rect.minZ, rect.maxX, rect.maxZ)
+	for i, quadrant := range quadrants {
+		if location == quadrant {
+			return n.children[i]
+		}
+	}
+	return nil
+}
+
+// split creates the four children of a leaf and moves its items down
+func (q *QuadTree[T]) split(node *quadNode[T]) {
+	b := node.border
+	centerX := b.X + b.Width/2
+	centerZ := b.Z + b.Height/2
+	borders := [4]Border{
+		NewBorder(b.X, centerZ, centerX-b.X, b.Z+b.Height-centerZ),
+		NewBorder(centerX, centerZ, b.X+b.Width-centerX, b.Z+b.Height-centerZ),
+		NewBorder(b.X, b.Z, centerX-b.X, centerZ-b.Z),
+		NewBorder(centerX, b.Z, b.X+b.Width-centerX, centerZ-b.Z),
+	}
+	for i := range node.children {
+		node.children[i] = &quadNode[T]{border: borders[i], depth: node.depth + 1, parent: node}
+	}
+
+	items := node.items
+	node.items = nil
+	for _, item := range items {
+		node.count--
+		q.insert(node, item, q.rects[item])
+	}
+}
+
+// Remove deletes an item from the tree, returns false if it does not exist
+func (q *QuadTree[T]) Remove(item T) bool {
+	node, exist := q.items[item]
+	if !exist {
+		return false
+	}
+	for i, v := range node.items {
+		if v == item {
+			node.items = append(node.items[:i], node.items[i+1:]...)
+			break
+		}
+	}
+	delete(q.items, item)
+	delete(q.rects, item)
+
+	// Collapse the highest ancestor whose subtree fits in one node
+	var collapse *quadNode[T]
+	for n := node; n != nil; n = n.parent {
+		n.count--
+		if n.children[0] != nil && n.count <= q.Capacity {
+			collapse = n
+		}
+	}
+	if collapse != nil {
+		q.collapse(collapse)
+	}
+	return true
+}
+
+// collapse moves every item of the subtree into the node and removes its children
+func (q *QuadTree[T]) collapse(node *quadNode[T]) {
+	var gather func(n *quadNode[T])
+	gather = func(n *quadNode[T]) {
+		for _, child := range n.children {
+			if child == nil {
+				continue
+			}
+			for _, item := range child.items {
+				node.items = append(node.items, item)
+				q.items[item] = node
+			}
+			gather(child)
+		}
+	}
+	gather(node)
+	node.children = [4]*quadNode[T]{}
+}
+
+// Move updates the position of an item after its bounding rectangle changed
+// Items not in the tree are inserted
+func (q *QuadTree[T]) Move(item T) {
+	rect := newAABB(item)
+	if old, exist := q.rects[item]; exist {
+		if old == rect {
+			return
+		}
+		q.Remove(item)
+	}
+	q.insert(q.root, item, rect)
+}
+
+// QueryRect returns items whose bounding rectangle overlaps the rectangle
+func (q *QuadTree[T]) QueryRect(r Rectangle) []T {
+	rect := rectAABB(r)
+	var ret []T
+	q.query(q.root, rect, func(item T, box aabb) {
+		if box.overlaps(rect) {
+			ret = append(ret, item)
+		}
+	})
+	return ret
+}
+
+// QueryCircle returns items whose bounding rectangle overlaps the circle
+func (q *QuadTree[T]) QueryCircle(c Circle) []T {
+	radiusSquared := float64(c.Radius) * float64(c.Radius)
+	var ret []T
+	q.query(q.root, circleAABB(c), func(item T, box aabb) {
+		if box.distSquared(c.Center) <= radiusSquared {
+			ret = append(ret, item)
+		}
+	})
+	return ret
+}
+
+// QueryCoord returns items whose bounding rectangle contains the point
+func (q *QuadTree[T]) QueryCoord(p Coord) []T {
+	var ret []T
+	q.query(q.root, aabb{minX: p.X, minZ: p.Z, maxX: p.X, maxZ: p.Z}, func(item T, box aabb) {
+		if box.contains(p) {
+			ret = append(ret, item)
+		}
+	})
+	return ret
+}
+
+// query visits the items of every node whose quadrant overlaps the rectangle
+func (q *QuadTree[T]) query(node *quadNode[T], rect aabb, visit func(item T, box aabb)) {
+	for _, item := range node.items {
+		visit(item, q.rects[item])
+	}
+	if node.children[0] == nil {
+		return
+	}
+	location := node.border.RectLocation(rect.minX, rect.minZ, rect.maxX, rect.maxZ)
+	for i, quadrant := range quadrants {
+		if location&quadrant != 0 {
+			q.query(node.children[i], rect, visit)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+// newQuadTreeCircles returns a quadtree over 0..100 with circles in each quadrant,
+// one crossing the center and one outside the border
+func newQuadTreeCircles(t *testing.T) (*QuadTree[*Circle], []*Circle) {
+	t.Helper()
+	circles := []*Circle{
+		{Center: Coord{X: 25, Z: 75}, Radius: 5},  // Left top
+		{Center: Coord{X: 75, Z: 75}, Radius: 5},  // Right top
+		{Center: Coord{X: 25, Z: 25}, Radius: 5},  // Left bottom
+		{Center: Coord{X: 75, Z: 25}, Radius: 5},  // Right bottom
+		{Center: Coord{X: 50, Z: 50}, Radius: 10}, // Crossing the center
+		{Center: Coord{X: 150, Z: 50}, Radius: 5}, // Outside the border
+	}
+	q := NewQuadTree[*Circle](NewBorder(0, 0, 100, 100), 3, 1)
+	for _, c := range circles {
+		if !q.Insert(c) {
+			t.Fatalf("Insert(%v) = false, want true", c)
+		}
+	}
+	return q, circles
+}
+
+// checkQuadTree checks that every item is in the deepest node containing it and that counts add up
+func checkQuadTree(t *testing.T, q *QuadTree[*Circle]) {
+	t.Helper()
+	var walk func(n *quadNode[*Circle]) int
+	walk = func(n *quadNode[*Circle]) int {
+		count := len(n.items)
+		for _, item := range n.items {
+			if q.items[item] != n {
+				t.Errorf("item %v is stored in another node", item)
+			}
+			if child := n.childOf(newAABB(item)); n.children[0] != nil && child != nil {
+				t.Errorf("item %v fits in a child of its node", item)
+			}
+		}
+		for _, child := range n.children {
+			if child != nil {
+				count += walk(child)
+			}
+		}
+		if n.count != count {
+			t.Errorf("node count = %d, want %d", n.count, count)
+		}
+		return count
+	}
+	if n := walk(q.root); n != q.Len() {
+		t.Errorf("tree holds %d items, Len() = %d", n, q.Len())
+	}
+}
+
+func TestQuadTreeInsert(t *testing.T) {
+	q, circles := newQuadTreeCircles(t)
+	checkQuadTree(t, q)
+
+	if q.root.children[0] == nil {
+		t.Fatal("root did not split")
+	}
+	for i := range quadrants {
+		if node := q.items[circles[i]]; node != q.root.children[i] {
+			t.Errorf("circle %d is not in quadrant %d", i, i)
+		}
+	}
+	for _, c := range circles[4:] {
+		if q.items[c] != q.root {
+			t.Errorf("circle %v is not in the root", c)
+		}
+	}
+	if q.Insert(circles[0]) || q.Len() != len(circles) {
+		t.Errorf("Insert() of an existing item = true or Len() = %d, want false and %d", q.Len(), len(circles))
+	}
+}
+
+func TestQuadTreeQuery(t *testing.T) {
+	q, circles := newQuadTreeCircles(t)
+	tests := []struct {
+		name string
+		got  []*Circle
+		want []int
+	}{
+		{name: "rect over the left half", got: q.QueryRect(NewRectangle(0, 0, 39, 100)), want: []int{0, 2}},
+		{name: "rect touching the crossing box", got: q.QueryRect(NewRectangle(0, 0, 40, 100)), want: []int{0, 2, 4}},
+		{name: "rect touching a box", got: q.QueryRect(NewRectangle(80, 80, 20, 20)), want: []int{1}},
+		{name: "rect outside the border", got: q.QueryRect(NewRectangle(140, 40, 5, 5)), want: []int{5}},
+		{name: "circle at the center", got: q.QueryCircle(NewCirCle(Coord{X: 50, Z: 50}, 1)), want: []int{4}},
+		{name: "circle reaching two boxes", got: q.QueryCircle(NewCirCle(Coord{X: 50, Z: 75}, 20)), want: []int{0, 1, 4}},
+		{name: "circle near a box corner", got: q.QueryCircle(NewCirCle(Coord{X: 90, Z: 90}, 14)), want: nil},
+		{name: "coord on a box edge", got: q.QueryCoord(Coord{X: 30, Z: 25}), want: []int{2}},
+		{name: "coord in no box", got: q.QueryCoord(Coord{X: 10, Z: 10}), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, c := range tt.got {
+				got = append(got, slices.Index(circles, c))
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("query = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuadTreeMoveAndRemove(t *testing.T) {
+	q, circles := newQuadTreeCircles(t)
+
+	// Moving the crossing circle into the right bottom quadrant pushes it down
+	circles[4].Center = Coord{X: 60, Z: 10}
+	circles[4].Radius = 5
+	q.Move(circles[4])
+	checkQuadTree(t, q)
+	if q.items[circles[4]] == q.root {
+		t.Error("Move() left the circle in the root")
+	}
+	if got := q.QueryCoord(Coord{X: 50, Z: 50}); len(got) != 0 {
+		t.Errorf("QueryCoord() at the old position = %v, want none", got)
+	}
+	if got := q.QueryCoord(Coord{X: 60, Z: 10}); len(got) != 1 || got[0] != circles[4] {
+		t.Errorf("QueryCoord() at the new position = %v, want the moved circle", got)
+	}
+
+	for _, c := range circles[1:] {
+		if !q.Remove(c) {
+			t.Fatalf("Remove(%v) = false, want true", c)
+		}
+		checkQuadTree(t, q)
+	}
+	if q.Remove(circles[1]) {
+		t.Error("Remove() of a missing item = true, want false")
+	}
+	if q.root.children[0] != nil || q.items[circles[0]] != q.root {
+		t.Error("Remove() did not collapse the tree into the root")
+	}
+	if got := q.QueryRect(NewRectangle(0, 0, 100, 100)); len(got) != 1 || got[0] != circles[0] {
+		t.Errorf("QueryRect() after Remove() = %v, want the remaining circle", got)
+	}
+}
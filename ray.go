@@ -0,0 +1,398 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Ray represents a half-line starting at an origin and going along a direction
+type Ray struct {
+	Origin Coord  // Start point of the ray
+	Dir    Vector // Direction of the ray, does not need to be normalized
+}
+
+// NewRay creates a new ray with given origin and direction
+func NewRay(origin Coord, dir Vector) Ray {
+	return Ray{
+		Origin: origin,
+		Dir:    dir,
+	}
+}
+
+// Line represents an infinite line through a point along a direction
+type Line struct {
+	Coord Coord  // Point of the line, distances along the line are measured from it
+	Dir   Vector // Direction of the line, does not need to be normalized
+}
+
+// NewLine creates a new line through a and b, directed from a to b
+func NewLine(a, b Coord) Line {
+	return Line{
+		Coord: a,
+		Dir:   NewVector(a, b),
+	}
+}
+
+// RayHit represents the first point where a ray or a line enters a shape
+type RayHit struct {
+	Index    int     // Index of the shape in the batch, 0 for single casts
+	Distance float64 // Distance from the origin along the direction, negative behind the point of a line
+	Coord    Coord   // Hit point
+	Normal   Vector  // Surface normal at the hit point, unit vector with length 1000
+}
+
+// CastSegment casts the ray against a line segment
+func (r *Ray) CastSegment(s *Segment) (RayHit, bool) {
+	rc := r.caster()
+	return rc.hit(rc.segment(coordPoint(s.A), coordPoint(s.B)))
+}
+
+// CastCircle casts the ray against a circle
+// A ray starting inside the circle hits it at distance 0 with the normal against the direction.
+func (r *Ray) CastCircle(c *Circle) (RayHit, bool) {
+	rc := r.caster()
+	return rc.hit(rc.circle(coordPoint(c.Center), float64(c.Radius)))
+}
+
+// CastConvex casts the ray against a convex polygon such as Rectangle, Triangle, Convex or OBB
+// A ray starting inside the polygon hits it at distance 0 with the normal against the direction.
+func (r *Ray) CastConvex(p ConvexPolygon) (RayHit, bool) {
+	rc := r.caster()
+	return rc.hit(rc.convex(satPoints(p.GetCoords())))
+}
+
+// Cast casts the ray against any shape
+// Shapes other than the ones of this package are hit only if they implement ConvexPolygon.
+func (r *Ray) Cast(s Shape) (RayHit, bool) {
+	rc := r.caster()
+	return rc.hit(rc.shape(s))
+}
+
+// CastAll casts the ray against every shape and returns the hits within maxDst sorted by distance
+// Pass math.Inf(1) as maxDst for an unlimited ray.
+func (r *Ray) CastAll(shapes []Shape, maxDst float64) []RayHit {
+	hits := castAll(r.caster(), shapes)
+	for i, hit := range hits {
+		if hit.Distance > maxDst {
+			return hits[:i]
+		}
+	}
+	return hits
+}
+
+// CastSegment casts the line against a line segment
+func (l *Line) CastSegment(s *Segment) (RayHit, bool) {
+	rc := l.caster()
+	return rc.hit(rc.segment(coordPoint(s.A), coordPoint(s.B)))
+}
+
+// CastCircle casts the line against a circle
+func (l *Line) CastCircle(c *Circle) (RayHit, bool) {
+	rc := l.caster()
+	return rc.hit(rc.circle(coordPoint(c.Center), float64(c.Radius)))
+}
+
+// CastConvex casts the line against a convex polygon such as Rectangle, Triangle, Convex or OBB
+func (l *Line) CastConvex(p ConvexPolygon) (RayHit, bool) {
+	rc := l.caster()
+	return rc.hit(rc.convex(satPoints(p.GetCoords())))
+}
+
+// Cast casts the line against any shape
+// Shapes other than the ones of this package are hit only if they implement ConvexPolygon.
+func (l *Line) Cast(s Shape) (RayHit, bool) {
+	rc := l.caster()
+	return rc.hit(rc.shape(s))
+}
+
+// CastAll casts the line against every shape and returns the hits sorted by distance
+func (l *Line) CastAll(shapes []Shape) []RayHit {
+	return castAll(l.caster(), shapes)
+}
+
+// caster returns the float form of the ray
+func (r *Ray) caster() *rayCaster {
+	return newRayCaster(r.Origin, r.Dir, 0)
+}
+
+// caster returns the float form of the line
+func (l *Line) caster() *rayCaster {
+	return newRayCaster(l.Coord, l.Dir, math.Inf(-1))
+}
+
+// castAll casts against every shape and sorts the hits by distance
+func castAll(rc *rayCaster, shapes []Shape) []RayHit {
+	var hits []RayHit
+	for i, s := range shapes {
+		if hit, ok := rc.hit(rc.shape(s)); ok {
+			hit.Index = i
+			hits = append(hits, hit)
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Distance < hits[j].Distance
+	})
+	return hits
+}
+
+// rayCaster represents a ray or a line with float coordinates
+// Points are origin + t*dir with t >= min, t is the distance from the origin.
+type rayCaster struct {
+	origin satPoint // Origin of the ray or point of the line
+	dir    satPoint // Unit direction, zero for degenerate directions
+	min    float64  // Smallest parameter, 0 for rays and -Inf for lines
+}
+
+// newRayCaster creates a caster from origin along dir
+func newRayCaster(origin Coord, dir Vector, min float64) *rayCaster {
+	rc := &rayCaster{origin: coordPoint(origin), min: min}
+	if length := dir.Length(); length != 0 {
+		rc.dir = satPoint{x: float64(dir.X) / length, z: float64(dir.Z) / length}
+	}
+	return rc
+}
+
+// at returns the point at parameter t
+func (rc *rayCaster) at(t float64) satPoint {
+	return satPoint{x: rc.origin.x + t*rc.dir.x, z: rc.origin.z + t*rc.dir.z}
+}
+
+// rayResult represents the parameter and the surface normal where a caster enters a shape
+type rayResult struct {
+	t      float64  // Parameter of the hit point
+	normal satPoint // Unit surface normal at the hit point
+	ok     bool     // Whether the shape is hit
+}
+
+// nearest returns the result with the smallest parameter
+func (r rayResult) nearest(o rayResult) rayResult {
+	if o.ok && (!r.ok || o.t < r.t) {
+		return o
+	}
+	return r
+}
+
+// inside returns the hit of a ray starting inside a shape
+func (rc *rayCaster) inside() rayResult {
+	return rayResult{t: rc.min, normal: satPoint{x: -rc.dir.x, z: -rc.dir.z}, ok: true}
+}
+
+// hit converts a result to a RayHit
+func (rc *rayCaster) hit(r rayResult) (RayHit, bool) {
+	if !r.ok || rc.dir == (satPoint{}) {
+		return RayHit{}, false
+	}
+	return RayHit{
+		Distance: r.t,
+		Coord:    satCoord(rc.at(r.t)),
+		Normal:   satVector(r.normal),
+	}, true
+}
+
+// shape dispatches the cast on the type of the shape
+func (rc *rayCaster) shape(s Shape) rayResult {
+	switch shape := s.(type) {
+	case *Circle:
+		return rc.circle(coordPoint(shape.Center), float64(shape.Radius))
+	case *Segment:
+		return rc.segment(coordPoint(shape.A), coordPoint(shape.B))
+	case *Capsule:
+		return rc.capsule(shape)
+	case *Sector:
+		return rc.sector(shape)
+	case *Annulus:
+		return rc.annulus(shape)
+	case *Ellipse:
+		return rc.ellipse(shape)
+	case ConvexPolygon:
+		return rc.convex(satPoints(shape.GetCoords()))
+	}
+	return rayResult{}
+}
+
+// circleRoots returns the parameters where the ray crosses the circle, t1 <= t2
+func (rc *rayCaster) circleRoots(c satPoint, r float64) (t1, t2 float64, ok bool) {
+	ox, oz := rc.origin.x-c.x, rc.origin.z-c.z
+	b := ox*rc.dir.x + oz*rc.dir.z
+	discriminant := b*b - (ox*ox + oz*oz - r*r)
+	if discriminant < 0 {
+		return 0, 0, false
+	}
+	sqrt := math.Sqrt(discriminant)
+	return -b - sqrt, -b + sqrt, true
+}
+
+// radial returns the unit direction from c to the point at parameter t
+func (rc *rayCaster) radial(c satPoint, t float64) satPoint {
+	p := rc.at(t)
+	dx, dz := p.x-c.x, p.z-c.z
+	length := math.Hypot(dx, dz)
+	if length == 0 {
+		return satPoint{x: -rc.dir.x, z: -rc.dir.z}
+	}
+	return satPoint{x: dx / length, z: dz / length}
+}
+
+// circle casts against a solid circle
+func (rc *rayCaster) circle(c satPoint, r float64) rayResult {
+	t1, t2, ok := rc.circleRoots(c, r)
+	if !ok || t2 < rc.min {
+		return rayResult{}
+	}
+	if t1 < rc.min {
+		return rc.inside()
+	}
+	return rayResult{t: t1, normal: rc.radial(c, t1), ok: true}
+}
+
+// segment casts against a line segment, the normal faces the ray
+func (rc *rayCaster) segment(a, b satPoint) rayResult {
+	ex, ez := b.x-a.x, b.z-a.z
+	wx, wz := a.x-rc.origin.x, a.z-rc.origin.z
+	denom := rc.dir.x*ez - rc.dir.z*ex
+	length := math.Hypot(ex, ez)
+	if math.Abs(denom) <= 1e-9*length {
+		// Parallel, only collinear segments are hit at their nearest end
+		if math.Abs(wx*rc.dir.z-wz*rc.dir.x) > 1e-9 {
+			return rayResult{}
+		}
+		ta := wx*rc.dir.x + wz*rc.dir.z
+		tb := (b.x-rc.origin.x)*rc.dir.x + (b.z-rc.origin.z)*rc.dir.z
+		if max(ta, tb) < rc.min {
+			return rayResult{}
+		}
+		return rayResult{t: max(min(ta, tb), rc.min), normal: satPoint{x: -rc.dir.x, z: -rc.dir.z}, ok: true}
+	}
+	t := (wx*ez - wz*ex) / denom
+	u := (wx*rc.dir.z - wz*rc.dir.x) / denom
+	if t < rc.min || u < 0 || u > 1 {
+		return rayResult{}
+	}
+	normal := satPoint{x: ez / length, z: -ex / length}
+	if normal.x*rc.dir.x+normal.z*rc.dir.z > 0 {
+		normal = satPoint{x: -normal.x, z: -normal.z}
+	}
+	return rayResult{t: t, normal: normal, ok: true}
+}
+
+// convex casts against a counter-clockwise convex polygon with Cyrus-Beck clipping
+// Reference: https://en.wikipedia.org/wiki/Cyrus%E2%80%93Beck_algorithm
+func (rc *rayCaster) convex(points []satPoint) rayResult {
+	enter, exit := math.Inf(-1), math.Inf(1)
+	var normal satPoint
+	for i := range points {
+		n := edgeNormal(points, i)
+		if n == (satPoint{}) {
+			continue
+		}
+		num := n.x*(points[i].x-rc.origin.x) + n.z*(points[i].z-rc.origin.z)
+		den := n.x*rc.dir.x + n.z*rc.dir.z
+		if den == 0 {
+			// Parallel to the edge and outside of it
+			if num < 0 {
+				return rayResult{}
+			}
+			continue
+		}
+		if t := num / den; den < 0 && t > enter {
+			enter, normal = t, n
+		} else if den > 0 {
+			exit = min(exit, t)
+		}
+	}
+	if enter > exit || exit < rc.min || normal == (satPoint{}) {
+		return rayResult{}
+	}
+	if enter < rc.min {
+		return rc.inside()
+	}
+	return rayResult{t: enter, normal: normal, ok: true}
+}
+
+// capsule casts against the two end circles and the box between them
+func (rc *rayCaster) capsule(c *Capsule) rayResult {
+	a, b := coordPoint(c.Segment.A), coordPoint(c.Segment.B)
+	r := float64(c.Radius)
+	res := rc.circle(a, r).nearest(rc.circle(b, r))
+	ex, ez := b.x-a.x, b.z-a.z
+	if length := math.Hypot(ex, ez); length != 0 {
+		nx, nz := -ez/length*r, ex/length*r
+		box := []satPoint{
+			{x: a.x - nx, z: a.z - nz},
+			{x: b.x - nx, z: b.z - nz},
+			{x: b.x + nx, z: b.z + nz},
+			{x: a.x + nx, z: a.z + nz},
+		}
+		res = res.nearest(rc.convex(box))
+	}
+	return res
+}
+
+// sector casts against the arc and the two straight edges of the sector
+func (rc *rayCaster) sector(s *Sector) rayResult {
+	if !math.IsInf(rc.min, -1) && s.IsCoordInside(satCoord(rc.at(rc.min))) {
+		return rc.inside()
+	}
+	center := coordPoint(s.Center)
+	var res rayResult
+	if t1, _, hit := rc.circleRoots(center, float64(s.Radius)); hit && t1 >= rc.min && s.inAngle(satCoord(rc.at(t1))) {
+		res = rayResult{t: t1, normal: rc.radial(center, t1), ok: true}
+	}
+	left, right := s.GetEdgeCoords()
+	res = res.nearest(rc.segment(center, coordPoint(left)))
+	return res.nearest(rc.segment(center, coordPoint(right)))
+}
+
+// annulus casts against the ring, entering through the outer circle or from the hole
+func (rc *rayCaster) annulus(a *Annulus) rayResult {
+	center := coordPoint(a.Center)
+	t1, t2, ok := rc.circleRoots(center, float64(a.OuterRadius))
+	if !ok || t2 < rc.min {
+		return rayResult{}
+	}
+	i1, i2, hole := rc.circleRoots(center, float64(a.InnerRadius))
+	if !hole || a.InnerRadius == 0 || rc.min <= i1 {
+		// The solid part before the hole is [t1, i1]
+		if t1 < rc.min {
+			return rc.inside()
+		}
+		return rayResult{t: t1, normal: rc.radial(center, t1), ok: true}
+	}
+	// The solid part after the hole is [i2, t2]
+	if i2 < rc.min {
+		return rc.inside()
+	}
+	n := rc.radial(center, i2)
+	return rayResult{t: i2, normal: satPoint{x: -n.x, z: -n.z}, ok: true}
+}
+
+// ellipse casts against the ellipse, solved in the frame where it is the unit circle
+func (rc *rayCaster) ellipse(e *Ellipse) rayResult {
+	if seg, ok := e.flat(); ok {
+		return rc.segment(coordPoint(seg.A), coordPoint(seg.B))
+	}
+	a, b := float64(e.RadiusX), float64(e.RadiusZ)
+	cos, sin := math.Cos(e.Angle), math.Sin(e.Angle)
+	ox, oz := rc.origin.x-float64(e.Center.X), rc.origin.z-float64(e.Center.Z)
+	ux, uz := (ox*cos+oz*sin)/a, (-ox*sin+oz*cos)/b
+	vx, vz := (rc.dir.x*cos+rc.dir.z*sin)/a, (-rc.dir.x*sin+rc.dir.z*cos)/b
+	qa := vx*vx + vz*vz
+	qb := ux*vx + uz*vz
+	discriminant := qb*qb - qa*(ux*ux+uz*uz-1)
+	if qa == 0 || discriminant < 0 {
+		return rayResult{}
+	}
+	sqrt := math.Sqrt(discriminant)
+	t1, t2 := (-qb-sqrt)/qa, (-qb+sqrt)/qa
+	if t2 < rc.min {
+		return rayResult{}
+	}
+	if t1 < rc.min {
+		return rc.inside()
+	}
+	// Gradient of the implicit equation in the local frame, rotated back
+	gx, gz := (ux+t1*vx)/a, (uz+t1*vz)/b
+	nx, nz := gx*cos-gz*sin, gx*sin+gz*cos
+	length := math.Hypot(nx, nz)
+	return rayResult{t: t1, normal: satPoint{x: nx / length, z: nz / length}, ok: true}
+}
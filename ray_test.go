@@ -0,0 +1,162 @@
+package geo
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRayCast(t *testing.T) {
+	ray := NewRay(Coord{}, Vector{X: 3, Z: 0})
+	tests := []struct {
+		name    string
+		ray     Ray
+		shape   Shape
+		want    RayHit
+		wantHit bool
+	}{
+		{
+			name: "segment", ray: ray, shape: ptr(NewSegment(Coord{X: 50, Z: -10}, Coord{X: 50, Z: 10})),
+			want: RayHit{Distance: 50, Coord: Coord{X: 50}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "collinear segment", ray: ray, shape: ptr(NewSegment(Coord{X: 40}, Coord{X: 20})),
+			want: RayHit{Distance: 20, Coord: Coord{X: 20}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "segment behind", ray: ray, shape: ptr(NewSegment(Coord{X: -50, Z: -10}, Coord{X: -50, Z: 10})),
+			wantHit: false,
+		},
+		{
+			name: "circle", ray: ray, shape: ptr(NewCirCle(Coord{X: 100}, 20)),
+			want: RayHit{Distance: 80, Coord: Coord{X: 80}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "inside a circle", ray: ray, shape: ptr(NewCirCle(Coord{}, 10)),
+			want: RayHit{Distance: 0, Coord: Coord{}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "circle beside", ray: ray, shape: ptr(NewCirCle(Coord{X: 100, Z: 21}, 20)),
+			wantHit: false,
+		},
+		{
+			name: "rectangle", ray: ray, shape: ptr(NewRectangle(30, -10, 20, 20)),
+			want: RayHit{Distance: 30, Coord: Coord{X: 30}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "rectangle on a diagonal", ray: NewRay(Coord{}, Vector{X: 1, Z: 1}), shape: ptr(NewRectangle(20, 10, 20, 100)),
+			want: RayHit{Distance: 20 * math.Sqrt2, Coord: Coord{X: 20, Z: 20}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "capsule side", ray: ray, shape: ptr(NewCapsule(Coord{X: 50, Z: -50}, Coord{X: 50, Z: 50}, 10)),
+			want: RayHit{Distance: 40, Coord: Coord{X: 40}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "capsule cap", ray: ray, shape: ptr(NewCapsule(Coord{X: 50, Z: 5}, Coord{X: 50, Z: 100}, 10)),
+			want: RayHit{Distance: 50 - 5*math.Sqrt(3), Coord: Coord{X: 41}, Normal: Vector{X: -866, Z: -500}}, wantHit: true,
+		},
+		{
+			name: "sector arc", ray: ray, shape: ptr(NewSector(Coord{X: 100}, 50, Vector{X: -1}, math.Pi/4)),
+			want: RayHit{Distance: 50, Coord: Coord{X: 50}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "sector edge", ray: ray, shape: ptr(NewSector(Coord{X: 100, Z: -50}, 100, Vector{Z: 1}, math.Pi/4)),
+			want: RayHit{Distance: 50, Coord: Coord{X: 50}, Normal: Vector{X: -707, Z: -707}}, wantHit: true,
+		},
+		{
+			name: "annulus", ray: ray, shape: ptr(NewAnnulus(Coord{X: 100}, 20, 40)),
+			want: RayHit{Distance: 60, Coord: Coord{X: 60}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "annulus from the hole", ray: NewRay(Coord{X: 100}, Vector{X: 1}), shape: ptr(NewAnnulus(Coord{X: 100}, 20, 40)),
+			want: RayHit{Distance: 20, Coord: Coord{X: 120}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "ellipse", ray: ray, shape: ptr(NewEllipse(Coord{X: 100}, 50, 20, 0)),
+			want: RayHit{Distance: 50, Coord: Coord{X: 50}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "rotated ellipse", ray: ray, shape: ptr(NewEllipse(Coord{X: 100}, 50, 20, math.Pi/2)),
+			want: RayHit{Distance: 80, Coord: Coord{X: 80}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name: "zero direction", ray: NewRay(Coord{}, Vector{}), shape: ptr(NewCirCle(Coord{}, 10)),
+			wantHit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := tt.ray.Cast(tt.shape)
+			if hit != tt.wantHit || got.Coord != tt.want.Coord || got.Normal != tt.want.Normal || math.Abs(got.Distance-tt.want.Distance) > 1e-9 {
+				t.Errorf("Cast() = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestLineCast(t *testing.T) {
+	line := NewLine(Coord{}, Coord{X: 10})
+	tests := []struct {
+		name    string
+		shape   Shape
+		want    RayHit
+		wantHit bool
+	}{
+		{
+			name:  "segment behind",
+			shape: ptr(NewSegment(Coord{X: -50, Z: -10}, Coord{X: -50, Z: 10})),
+			want:  RayHit{Distance: -50, Coord: Coord{X: -50}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name:  "circle around the point",
+			shape: ptr(NewCirCle(Coord{}, 10)),
+			want:  RayHit{Distance: -10, Coord: Coord{X: -10}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name:  "rectangle ahead",
+			shape: ptr(NewRectangle(30, -10, 20, 20)),
+			want:  RayHit{Distance: 30, Coord: Coord{X: 30}, Normal: Vector{X: -1000}}, wantHit: true,
+		},
+		{
+			name:    "circle beside",
+			shape:   ptr(NewCirCle(Coord{X: -100, Z: 30}, 20)),
+			wantHit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := line.Cast(tt.shape)
+			if hit != tt.wantHit || got != tt.want {
+				t.Errorf("Cast() = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestCastAll(t *testing.T) {
+	shapes := []Shape{
+		ptr(NewCirCle(Coord{X: 100}, 20)),
+		ptr(NewRectangle(30, -10, 20, 20)),
+		ptr(NewSegment(Coord{X: -50, Z: -10}, Coord{X: -50, Z: 10})),
+		ptr(NewCirCle(Coord{X: 50, Z: 100}, 20)),
+	}
+	indices := func(hits []RayHit) []int {
+		ret := make([]int, len(hits))
+		for i, hit := range hits {
+			ret[i] = hit.Index
+		}
+		return ret
+	}
+
+	ray := NewRay(Coord{}, Vector{X: 1})
+	if got := indices(ray.CastAll(shapes, math.Inf(1))); !slices.Equal(got, []int{1, 0}) {
+		t.Errorf("Ray.CastAll() = %v, want [1 0]", got)
+	}
+	if got := indices(ray.CastAll(shapes, 50)); !slices.Equal(got, []int{1}) {
+		t.Errorf("Ray.CastAll() within 50 = %v, want [1]", got)
+	}
+	line := NewLine(Coord{}, Coord{X: 1})
+	if got := indices(line.CastAll(shapes)); !slices.Equal(got, []int{2, 1, 0}) {
+		t.Errorf("Line.CastAll() = %v, want [2 1 0]", got)
+	}
+}
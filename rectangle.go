@@ -39,6 +39,27 @@ func (rec *Rectangle) GetVerticeCoords() [4]Coord {
 	return p
 }
 
+// GetCoords returns the 4 vertex coordinates in counter-clockwise order
+func (rec *Rectangle) GetCoords() []Coord {
+	coords := rec.GetVerticeCoords()
+	return coords[:]
+}
+
+// convexPolygon marks the rectangle as a ConvexPolygon
+func (rec *Rectangle) convexPolygon() {}
+
+// Support returns the rectangle corner farthest in the direction
+func (rec *Rectangle) Support(dir Vector) Coord {
+	p := rec.Coord
+	if dir.X > 0 {
+		p.X += rec.Width
+	}
+	if dir.Z > 0 {
+		p.Z += rec.Height
+	}
+	return p
+}
+
 // GetVectors returns 4 edge vectors in counter-clockwise order
 func (rec *Rectangle) GetVectors() [4]Vector {
 	coords := rec.GetVerticeCoords()
@@ -50,6 +71,12 @@ func (rec *Rectangle) GetVectors() [4]Vector {
 	}
 }
 
+// ToRect returns the bounding rectangle, which is the rectangle itself
+// Returns the minimum and maximum X,Z coordinates of the bounding rectangle
+func (rec *Rectangle) ToRect() (minX, minZ, maxX, maxZ int32) {
+	return rec.X, rec.Z, rec.X + rec.Width, rec.Z + rec.Height
+}
+
 // GetLocationToBorder returns the positional relationship between rectangle and given border
 func (rec *Rectangle) GetLocationToBorder(b *Border) LocationState {
 	minX := rec.X
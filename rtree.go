@@ -0,0 +1,267 @@
+package geo
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// rtreeNodeCapacity is the node capacity of R-trees built by the package
+const rtreeNodeCapacity = 16
+
+// PolygonRTree represents a static R-tree of polygons bulk loaded with Sort-Tile-Recursive
+// Reference: https://archive.org/details/nasa_techdoc_19970016975
+type PolygonRTree struct {
+	Polygons []Polygon // Indexed polygons
+
+	root     *rtreeNode
+	capacity int
+}
+
+// rtreeNode represents a node of the R-tree
+type rtreeNode struct {
+	box      aabb
+	children []*rtreeNode // Child nodes, nil for leaves
+	entries  []int        // Polygon positions of a leaf
+}
+
+// NewPolygonRTree bulk loads an R-tree from polygons
+// capacity: maximum number of entries in a node
+func NewPolygonRTree(polygons []Polygon, capacity int) *PolygonRTree {
+	t := &PolygonRTree{
+		Polygons: polygons,
+		capacity: max(capacity, 2),
+	}
+	if len(polygons) == 0 {
+		return t
+	}
+
+	nodes := make([]*rtreeNode, len(polygons))
+	for i, poly := range polygons {
+		nodes[i] = &rtreeNode{box: newAABB(poly), entries: []int{i}}
+	}
+	// Leaves are packed from single polygon nodes and then flattened
+	level := t.pack(nodes)
+	for _, leaf := range level {
+		entries := make([]int, 0, len(leaf.children))
+		for _, child := range leaf.children {
+			entries = append(entries, child.entries...)
+		}
+		leaf.entries = entries
+		leaf.children = nil
+	}
+	for len(level) > 1 {
+		level = t.pack(level)
+	}
+	t.root = level[0]
+	return t
+}
+
+// pack groups nodes into parent nodes with Sort-Tile-Recursive
+func (t *PolygonRTree) pack(nodes []*rtreeNode) []*rtreeNode {
+	numOfParents := (len(nodes) + t.capacity - 1) / t.capacity
+	numOfSlices := int(math.Ceil(math.Sqrt(float64(numOfParents))))
+	sliceSize := numOfSlices * t.capacity
+
+	// Sort by center X, then by center Z inside every vertical slice
+	sort.Slice(nodes, func(i, j int) bool {
+		return int64(nodes[i].box.minX)+int64(nodes[i].box.maxX) < int64(nodes[j].box.minX)+int64(nodes[j].box.maxX)
+	})
+	parents := make([]*rtreeNode, 0, numOfParents)
+	for start := 0; start < len(nodes); start += sliceSize {
+		slice := nodes[start:min(start+sliceSize, len(nodes))]
+		sort.Slice(slice, func(i, j int) bool {
+			return int64(slice[i].box.minZ)+int64(slice[i].box.maxZ) < int64(slice[j].box.minZ)+int64(slice[j].box.maxZ)
+		})
+		for i := 0; i < len(slice); i += t.capacity {
+			children := slice[i:min(i+t.capacity, len(slice))]
+			parent := &rtreeNode{box: children[0].box, children: append([]*rtreeNode(nil), children...)}
+			for _, child := range children[1:] {
+				parent.box = parent.box.union(child.box)
+			}
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// Len returns the number of polygons in the tree
+func (t *PolygonRTree) Len() int {
+	return len(t.Polygons)
+}
+
+// LocateCoord returns the first polygon of Polygons containing the point
+func (t *PolygonRTree) LocateCoord(p Coord) (Polygon, bool) {
+	index := t.locate(p, nil)
+	if index < 0 {
+		return nil, false
+	}
+	return t.Polygons[index], true
+}
+
+// locate returns the lowest position of the polygons containing the point and accepted by pass, or -1
+// Points on shared edges are inside several polygons, the lowest position keeps the result independent of the tree layout.
+// pass: nil accepts every polygon
+func (t *PolygonRTree) locate(p Coord, pass func(poly Polygon) bool) int {
+	ret := -1
+	t.search(aabb{minX: p.X, minZ: p.Z, maxX: p.X, maxZ: p.Z}, func(index int) bool {
+		if ret >= 0 && index > ret {
+			return true
+		}
+		if poly := t.Polygons[index]; poly.IsCoordInside(p) && (pass == nil || pass(poly)) {
+			ret = index
+		}
+		return true
+	})
+	return ret
+}
+
+// QueryCoord returns all polygons containing the point
+func (t *PolygonRTree) QueryCoord(p Coord) []Polygon {
+	var ret []Polygon
+	t.search(aabb{minX: p.X, minZ: p.Z, maxX: p.X, maxZ: p.Z}, func(index int) bool {
+		if t.Polygons[index].IsCoordInside(p) {
+			ret = append(ret, t.Polygons[index])
+		}
+		return true
+	})
+	return ret
+}
+
+// QueryRect returns polygons whose bounding rectangle overlaps the rectangle
+func (t *PolygonRTree) QueryRect(r Rectangle) []Polygon {
+	var ret []Polygon
+	t.search(rectAABB(r), func(index int) bool {
+		ret = append(ret, t.Polygons[index])
+		return true
+	})
+	return ret
+}
+
+// search visits the polygons whose bounding box overlaps the box until visit returns false
+func (t *PolygonRTree) search(box aabb, visit func(index int) bool) {
+	if t.root == nil {
+		return
+	}
+	stack := []*rtreeNode{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !node.box.overlaps(box) {
+			continue
+		}
+		if node.children == nil {
+			for _, index := range node.entries {
+				if newAABB(t.Polygons[index]).overlaps(box) && !visit(index) {
+					return
+				}
+			}
+			continue
+		}
+		stack = append(stack, node.children...)
+	}
+}
+
+// Nearest returns the k polygons nearest to the point, sorted by distance
+// The distance is 0 for polygons containing the point, nil is returned when k is not positive
+func (t *PolygonRTree) Nearest(p Coord, k int) []Polygon {
+	if k <= 0 {
+		return nil
+	}
+	ret := make([]Polygon, 0, min(k, len(t.Polygons)))
+	t.nearest(p, func(index int, _ float64) bool {
+		ret = append(ret, t.Polygons[index])
+		return len(ret) < k
+	})
+	return ret
+}
+
+// nearest visits polygons in ascending distance to the point until visit returns false
+func (t *PolygonRTree) nearest(p Coord, visit func(index int, dst float64) bool) {
+	if t.root == nil {
+		return
+	}
+	// Box distances are lower bounds of the polygon distances inside
+	queue := &rtreeQueue{{node: t.root, dst: math.Sqrt(t.root.box.distSquared(p))}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(rtreeQueueItem)
+		switch {
+		case item.node == nil:
+			if !visit(item.index, item.dst) {
+				return
+			}
+		case item.node.children == nil:
+			for _, index := range item.node.entries {
+				heap.Push(queue, rtreeQueueItem{index: index, dst: CalDstCoordToPolygon(p, t.Polygons[index])})
+			}
+		default:
+			for _, child := range item.node.children {
+				heap.Push(queue, rtreeQueueItem{node: child, dst: math.Sqrt(child.box.distSquared(p))})
+			}
+		}
+	}
+}
+
+// union returns the box containing both boxes
+func (a aabb) union(o aabb) aabb {
+	return aabb{
+		minX: min(a.minX, o.minX),
+		minZ: min(a.minZ, o.minZ),
+		maxX: max(a.maxX, o.maxX),
+		maxZ: max(a.maxZ, o.maxZ),
+	}
+}
+
+// CalDstCoordToPolygon calculates the distance from a point to a polygon
+// Returns 0 when the point is inside the polygon
+func CalDstCoordToPolygon(p Coord, poly Polygon) float64 {
+	if poly.IsCoordInside(p) {
+		return 0
+	}
+	dst := math.MaxFloat64
+	for _, ring := range polygonRings(poly) {
+		for i := range ring {
+			seg := NewSegment(ring[i], ring[(i+1)%len(ring)])
+			dst = min(dst, seg.CalCoordDst(p))
+		}
+	}
+	return dst
+}
+
+// polygonRings returns the boundary rings of a polygon
+func polygonRings(poly Polygon) [][]Coord {
+	if p, ok := poly.(*PolygonWithHoles); ok {
+		rings := [][]Coord{p.Outer.GetCoords()}
+		for i := range p.Holes {
+			rings = append(rings, p.Holes[i].GetCoords())
+		}
+		return rings
+	}
+	return [][]Coord{verticeCoords(poly.GetVertices())}
+}
+
+// rtreeQueueItem represents a node or a polygon in the nearest neighbor search
+type rtreeQueueItem struct {
+	node  *rtreeNode // Node to expand, nil for polygons
+	index int        // Polygon position
+	dst   float64    // Distance to the point, a lower bound for nodes
+}
+
+// rtreeQueue is a min-heap of nearest neighbor search items
+type rtreeQueue []rtreeQueueItem
+
+func (q rtreeQueue) Len() int           { return len(q) }
+func (q rtreeQueue) Less(i, j int) bool { return q[i].dst < q[j].dst }
+func (q rtreeQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *rtreeQueue) Push(x any) {
+	*q = append(*q, x.(rtreeQueueItem))
+}
+
+func (q *rtreeQueue) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
@@ -0,0 +1,132 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+// newSquareGrid returns n x n squares of size 10 spaced 20 apart, row by row
+func newSquareGrid(n int32) []Polygon {
+	var polygons []Polygon
+	for z := range n {
+		for x := range n {
+			polygons = append(polygons, &Convex{
+				Index:    int32(len(polygons)),
+				Vertices: squareVertices(x*20, z*20, x*20+10, z*20+10),
+			})
+		}
+	}
+	return polygons
+}
+
+func TestPolygonRTreePack(t *testing.T) {
+	tree := NewPolygonRTree(newSquareGrid(10), 4)
+	seen := make([]bool, tree.Len())
+	var walk func(node *rtreeNode)
+	walk = func(node *rtreeNode) {
+		if len(node.children) > 4 || len(node.entries) > 4 {
+			t.Errorf("node holds %d children and %d entries, want at most 4", len(node.children), len(node.entries))
+		}
+		for _, child := range node.children {
+			if !node.box.containsAABB(child.box) {
+				t.Errorf("node box %v does not contain child box %v", node.box, child.box)
+			}
+			walk(child)
+		}
+		for _, index := range node.entries {
+			if seen[index] {
+				t.Errorf("polygon %d is in several leaves", index)
+			}
+			seen[index] = true
+			if !node.box.containsAABB(newAABB(tree.Polygons[index])) {
+				t.Errorf("leaf box %v does not contain polygon %d", node.box, index)
+			}
+		}
+	}
+	walk(tree.root)
+	if i := slices.Index(seen, false); i >= 0 {
+		t.Errorf("polygon %d is in no leaf", i)
+	}
+}
+
+func TestPolygonRTreeLocateCoord(t *testing.T) {
+	tree := NewPolygonRTree(newSquareGrid(10), 4)
+	tests := []struct {
+		p    Coord
+		want int32 // Index of the polygon, -1 for none
+	}{
+		{p: Coord{X: 5, Z: 5}, want: 0},
+		{p: Coord{X: 185, Z: 185}, want: 99},
+		{p: Coord{X: 40, Z: 60}, want: 32},
+		{p: Coord{X: 50, Z: 70}, want: 32},
+		{p: Coord{X: 15, Z: 5}, want: -1},
+		{p: Coord{X: -1, Z: 0}, want: -1},
+	}
+	for _, tt := range tests {
+		got, ok := tree.LocateCoord(tt.p)
+		switch {
+		case tt.want < 0 && ok:
+			t.Errorf("LocateCoord(%v) = %d, want none", tt.p, got.GetIndex())
+		case tt.want >= 0 && (!ok || got.GetIndex() != tt.want):
+			t.Errorf("LocateCoord(%v) = %v, %v, want %d", tt.p, got, ok, tt.want)
+		}
+	}
+
+	// Points on a shared edge belong to the first polygon of the list
+	left := &Convex{Index: 7, Vertices: squareVertices(0, 0, 10, 10)}
+	right := &Convex{Index: 3, Vertices: squareVertices(10, 0, 20, 10)}
+	for _, polygons := range [][]Polygon{{left, right}, {right, left}} {
+		tree := NewPolygonRTree(polygons, 2)
+		if got, ok := tree.LocateCoord(Coord{X: 10, Z: 5}); !ok || got != polygons[0] {
+			t.Errorf("LocateCoord() on the shared edge = %v, want %v", got, polygons[0])
+		}
+		if got := tree.QueryCoord(Coord{X: 10, Z: 5}); len(got) != 2 {
+			t.Errorf("QueryCoord() on the shared edge = %d polygons, want 2", len(got))
+		}
+	}
+}
+
+func TestPolygonRTreeQueryRect(t *testing.T) {
+	tree := NewPolygonRTree(newSquareGrid(10), 4)
+	got := tree.QueryRect(NewRectangle(25, 25, 30, 20))
+	indices := make([]int32, len(got))
+	for i, poly := range got {
+		indices[i] = poly.GetIndex()
+	}
+	slices.Sort(indices)
+	if want := []int32{11, 12, 21, 22}; !slices.Equal(indices, want) {
+		t.Errorf("QueryRect() = %v, want %v", indices, want)
+	}
+}
+
+func TestPolygonRTreeNearest(t *testing.T) {
+	tree := NewPolygonRTree(newSquareGrid(10), 4)
+	p := Coord{X: 55, Z: 5}
+	got := tree.Nearest(p, 4)
+	indices := make([]int32, len(got))
+	for i, poly := range got {
+		indices[i] = poly.GetIndex()
+	}
+	// Squares 2 and 3 are 5 away, 12 and 13 are sqrt(5*5+15*15) away, 1 and 4 are 25 away
+	if len(indices) != 4 ||
+		!slices.Equal(slices.Sorted(slices.Values(indices[:2])), []int32{2, 3}) ||
+		!slices.Equal(slices.Sorted(slices.Values(indices[2:])), []int32{12, 13}) {
+		t.Errorf("Nearest() = %v, want 2 and 3 then 12 and 13", indices)
+	}
+
+	all := tree.Nearest(Coord{X: 93, Z: 71}, 1000)
+	if len(all) != tree.Len() {
+		t.Fatalf("Nearest() returned %d polygons, want %d", len(all), tree.Len())
+	}
+	for i := 1; i < len(all); i++ {
+		if CalDstCoordToPolygon(Coord{X: 93, Z: 71}, all[i]) < CalDstCoordToPolygon(Coord{X: 93, Z: 71}, all[i-1]) {
+			t.Fatalf("Nearest() is not sorted at %d", i)
+		}
+	}
+
+	for _, k := range []int{0, -1} {
+		if got := tree.Nearest(p, k); got != nil {
+			t.Errorf("Nearest(%d) = %v, want nil", k, got)
+		}
+	}
+}
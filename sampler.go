@@ -0,0 +1,227 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// samplerMaxAttempts is the number of rejected samples before a restricted draw gives up
+const samplerMaxAttempts = 64
+
+// PolygonSampler draws uniformly distributed random points over a set of polygons
+// Polygons are split into triangles chosen with a probability proportional to their area.
+type PolygonSampler struct {
+	Polygons []Polygon // Sampled polygons
+
+	triangles []sampleTriangle // Triangles of the polygons
+	areas     []float64        // Cumulative triangle areas
+}
+
+// sampleTriangle represents a triangle of a sampled polygon
+type sampleTriangle struct {
+	slot    int        // Position of the polygon in Polygons
+	a, b, c satPoint   // Vertices of the triangle
+	box     [4]float64 // Bounding box as minX, minZ, maxX, maxZ
+	corners []Coord    // Vertices inside the polygon, the last resort of rounded points
+}
+
+// NewPolygonSampler creates a sampler over the polygons
+// Triangles and Convex polygons are fanned, other polygons are triangulated with EarClipping
+// and skipped when it fails. Triangles without a vertex inside their polygon hold no
+// coordinate a sample can be moved to and are skipped as well.
+func NewPolygonSampler(polygons []Polygon) *PolygonSampler {
+	s := &PolygonSampler{Polygons: polygons}
+	total := 0.
+	for slot, poly := range polygons {
+		for _, t := range polygonTriangles(poly) {
+			st := newSampleTriangle(slot, t)
+			for _, c := range t {
+				if poly.IsCoordInside(c) {
+					st.corners = append(st.corners, c)
+				}
+			}
+			area := st.area()
+			if area == 0 || len(st.corners) == 0 {
+				continue
+			}
+			total += area
+			s.triangles = append(s.triangles, st)
+			s.areas = append(s.areas, total)
+		}
+	}
+	return s
+}
+
+// polygonTriangles splits a polygon into triangles
+func polygonTriangles(poly Polygon) [][3]Coord {
+	var vertices []Vertice
+	switch p := poly.(type) {
+	case *Triangle, *Convex:
+		vertices = p.GetVertices()
+	default:
+		rings := polygonRings(poly)
+		triangles, err := EarClipping(rings[0], rings[1:])
+		if err != nil {
+			return nil
+		}
+		ret := make([][3]Coord, len(triangles))
+		for i, t := range triangles {
+			ret[i] = [3]Coord{t.Vertices[0].Coord, t.Vertices[1].Coord, t.Vertices[2].Coord}
+		}
+		return ret
+	}
+	ret := make([][3]Coord, 0, max(len(vertices)-2, 0))
+	for i := 2; i < len(vertices); i++ {
+		ret = append(ret, [3]Coord{vertices[0].Coord, vertices[i-1].Coord, vertices[i].Coord})
+	}
+	return ret
+}
+
+// newSampleTriangle creates a sampled triangle of the polygon at slot
+func newSampleTriangle(slot int, t [3]Coord) sampleTriangle {
+	st := sampleTriangle{slot: slot, a: coordPoint(t[0]), b: coordPoint(t[1]), c: coordPoint(t[2])}
+	st.box = [4]float64{
+		min(st.a.x, st.b.x, st.c.x), min(st.a.z, st.b.z, st.c.z),
+		max(st.a.x, st.b.x, st.c.x), max(st.a.z, st.b.z, st.c.z),
+	}
+	return st
+}
+
+// area returns the area of the triangle
+func (t *sampleTriangle) area() float64 {
+	return math.Abs((t.b.x-t.a.x)*(t.c.z-t.a.z)-(t.c.x-t.a.x)*(t.b.z-t.a.z)) / 2
+}
+
+// point returns a uniformly distributed point of the triangle with barycentric sampling
+func (t *sampleTriangle) point(r *rand.Rand) satPoint {
+	u, v := randFloat(r), randFloat(r)
+	// Points of the other half of the parallelogram are folded back into the triangle
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	return satPoint{
+		x: t.a.x + u*(t.b.x-t.a.x) + v*(t.c.x-t.a.x),
+		z: t.a.z + u*(t.b.z-t.a.z) + v*(t.c.z-t.a.z),
+	}
+}
+
+// contains checks if the point is inside the triangle, points on the edges are inside
+func (t *sampleTriangle) contains(p satPoint) bool {
+	d1 := (t.b.x-t.a.x)*(p.z-t.a.z) - (t.b.z-t.a.z)*(p.x-t.a.x)
+	d2 := (t.c.x-t.b.x)*(p.z-t.b.z) - (t.c.z-t.b.z)*(p.x-t.b.x)
+	d3 := (t.a.x-t.c.x)*(p.z-t.c.z) - (t.a.z-t.c.z)*(p.x-t.c.x)
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
+
+// Area returns the total area of the sampled polygons
+func (s *PolygonSampler) Area() float64 {
+	if len(s.areas) == 0 {
+		return 0
+	}
+	return s.areas[len(s.areas)-1]
+}
+
+// RandCoord returns a uniformly distributed random point with the polygon holding it
+// r: source of randomness, the global source of math/rand is used when nil
+// Returns false when there is nothing to sample.
+func (s *PolygonSampler) RandCoord(r *rand.Rand) (Coord, Polygon, bool) {
+	if len(s.triangles) == 0 {
+		return Coord{}, nil, false
+	}
+	t := &s.triangles[pickArea(s.areas, randFloat(r))]
+	return s.accept(t, t.point(r)), s.Polygons[t.slot], true
+}
+
+// RandCoordInCircle returns a uniformly distributed random point of the polygons inside the circle
+// with the polygon holding it, sampled from the circle or from the overlapping triangles whichever is smaller.
+// r: source of randomness, the global source of math/rand is used when nil
+// Returns false when no point is found, mostly when the circle does not overlap the polygons.
+func (s *PolygonSampler) RandCoordInCircle(r *rand.Rand, c Circle) (Coord, Polygon, bool) {
+	center, radius := coordPoint(c.Center), float64(c.Radius)
+	var candidates []*sampleTriangle
+	var areas []float64
+	total := 0.
+	for i := range s.triangles {
+		t := &s.triangles[i]
+		dx := max(t.box[0]-center.x, center.x-t.box[2], 0)
+		dz := max(t.box[1]-center.z, center.z-t.box[3], 0)
+		if dx*dx+dz*dz > radius*radius {
+			continue
+		}
+		total += t.area()
+		candidates = append(candidates, t)
+		areas = append(areas, total)
+	}
+	if len(candidates) == 0 {
+		return Coord{}, nil, false
+	}
+
+	fromCircle := math.Pi*radius*radius < total
+	for range samplerMaxAttempts {
+		var t *sampleTriangle
+		var p satPoint
+		if fromCircle {
+			// Uniform point in the circle kept when it lands on a triangle
+			dst := radius * math.Sqrt(randFloat(r))
+			angle := randFloat(r) * 2 * math.Pi
+			p = satPoint{x: center.x + dst*math.Cos(angle), z: center.z + dst*math.Sin(angle)}
+			for _, candidate := range candidates {
+				if candidate.contains(p) {
+					t = candidate
+					break
+				}
+			}
+			if t == nil {
+				continue
+			}
+		} else {
+			// Uniform point of the triangles kept when it lands in the circle
+			t = candidates[pickArea(areas, randFloat(r))]
+			p = t.point(r)
+			if dx, dz := p.x-center.x, p.z-center.z; dx*dx+dz*dz > radius*radius {
+				continue
+			}
+		}
+		if coord := s.accept(t, p); c.IsCoordInside(coord) {
+			return coord, s.Polygons[t.slot], true
+		}
+	}
+	return Coord{}, nil, false
+}
+
+// pickArea returns the position of the triangle holding the fraction u of the cumulative areas
+func pickArea(areas []float64, u float64) int {
+	return min(sort.SearchFloat64s(areas, u*areas[len(areas)-1]), len(areas)-1)
+}
+
+// accept rounds the sampled point of the triangle to a coordinate inside its polygon
+// Rounded points outside the polygon move to the closest coordinate inside like closestPolygonCoord,
+// or to the closest vertex of the triangle when no coordinate around the boundary is inside.
+func (s *PolygonSampler) accept(t *sampleTriangle, p satPoint) Coord {
+	poly := s.Polygons[t.slot]
+	coord := satCoord(p)
+	if poly.IsCoordInside(coord) {
+		return coord
+	}
+	if closest := closestPolygonCoord(poly, coord); poly.IsCoordInside(closest) {
+		return closest
+	}
+	ret := t.corners[0]
+	for _, c := range t.corners[1:] {
+		if CalDstCoordToCoordWithoutSqrt(coord, c) < CalDstCoordToCoordWithoutSqrt(coord, ret) {
+			ret = c
+		}
+	}
+	return ret
+}
+
+// randFloat returns a random number in [0, 1) from r, or from the global source when r is nil
+func randFloat(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
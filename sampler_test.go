@@ -0,0 +1,92 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPolygonSamplerThinTriangles(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices []Vertice
+	}{
+		{
+			// Area 0.5 with the vertices as its only coordinates
+			name:     "sliver",
+			vertices: []Vertice{{Coord: Coord{X: 0, Z: 0}}, {Coord: Coord{X: 1000, Z: 1}}, {Coord: Coord{X: 2001, Z: 2}}},
+		},
+		{
+			name:     "half unit",
+			vertices: []Vertice{{Coord: Coord{X: 0, Z: 0}}, {Coord: Coord{X: 0, Z: 1}}, {Coord: Coord{X: 1, Z: 0}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tri := &Triangle{Vertices: tt.vertices}
+			s := NewPolygonSampler([]Polygon{tri})
+			r := rand.New(rand.NewSource(1))
+			for range 1000 {
+				p, poly, ok := s.RandCoord(r)
+				if !ok {
+					t.Fatal("RandCoord() found no point")
+				}
+				if poly != Polygon(tri) || !tri.IsCoordInside(p) {
+					t.Fatalf("RandCoord() = %v outside the triangle", p)
+				}
+			}
+		})
+	}
+}
+
+func TestPolygonSamplerDistribution(t *testing.T) {
+	small := &Convex{Vertices: squareVertices(0, 0, 100, 100)}
+	large := &Convex{Vertices: squareVertices(200, 0, 500, 100)}
+	s := NewPolygonSampler([]Polygon{small, large})
+	if s.Area() != 40000 {
+		t.Errorf("Area() = %v, want 40000", s.Area())
+	}
+
+	r := rand.New(rand.NewSource(1))
+	const n = 20000
+	hits := 0
+	for range n {
+		p, poly, ok := s.RandCoord(r)
+		if !ok || !poly.IsCoordInside(p) {
+			t.Fatalf("RandCoord() = %v, %v outside its polygon", p, ok)
+		}
+		if poly == Polygon(small) {
+			hits++
+		}
+	}
+	if got := float64(hits) / n; math.Abs(got-0.25) > 0.02 {
+		t.Errorf("RandCoord() picked the small square %.3f of the time, want 0.25", got)
+	}
+}
+
+func TestPolygonSamplerRandCoordInCircle(t *testing.T) {
+	square := &Convex{Vertices: squareVertices(0, 0, 1000, 1000)}
+	s := NewPolygonSampler([]Polygon{square})
+	r := rand.New(rand.NewSource(1))
+	for _, c := range []Circle{NewCirCle(Coord{X: 500, Z: 500}, 10), NewCirCle(Coord{X: 0, Z: 0}, 5000)} {
+		for range 100 {
+			p, _, ok := s.RandCoordInCircle(r, c)
+			if !ok || !c.IsCoordInside(p) || !square.IsCoordInside(p) {
+				t.Fatalf("RandCoordInCircle(%v) = %v, %v, want a point of the square in the circle", c, p, ok)
+			}
+		}
+	}
+	if _, _, ok := s.RandCoordInCircle(r, NewCirCle(Coord{X: 5000, Z: 5000}, 10)); ok {
+		t.Error("RandCoordInCircle() found a point in a circle away from the polygons")
+	}
+}
+
+// squareVertices returns the counter-clockwise vertices of the rectangle between two corners
+func squareVertices(minX, minZ, maxX, maxZ int32) []Vertice {
+	return []Vertice{
+		{Index: 0, Coord: Coord{X: minX, Z: minZ}},
+		{Index: 1, Coord: Coord{X: maxX, Z: minZ}},
+		{Index: 2, Coord: Coord{X: maxX, Z: maxZ}},
+		{Index: 3, Coord: Coord{X: minX, Z: maxZ}},
+	}
+}
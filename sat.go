@@ -0,0 +1,267 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/busyster996/geo/util"
+)
+
+// Contact represents the overlap between two shapes
+// Moving the second shape by Normal*Depth/1000, or the first one by the opposite, separates them.
+type Contact struct {
+	Normal Vector  // Collision normal from the first shape to the second, unit vector with length 1000
+	Depth  float64 // Penetration depth along the normal
+	Coord  Coord   // Contact point
+}
+
+// ConvexPolygon represents a convex shape described by its vertex coordinates
+// Triangle, Convex, Rectangle and OBB implement it. The unexported marker keeps
+// polygons that may be concave, such as SimplePolygon, out of the convex algorithms.
+type ConvexPolygon interface {
+	GetCoords() []Coord // Vertex coordinates in counter-clockwise order
+	convexPolygon()     // Marks the shape as convex
+}
+
+// CollideConvex checks if two convex polygons overlap with the Separating Axis Theorem
+// Returns the contact with the minimum translation, touching polygons collide with depth 0
+// Reference: https://dyn4j.org/2010/01/sat/
+func CollideConvex(a, b ConvexPolygon) (Contact, bool) {
+	return collideCoords(a.GetCoords(), b.GetCoords())
+}
+
+// CollideConvex checks if the circle overlaps a convex polygon with the Separating Axis Theorem
+// The contact normal points from the circle to the polygon
+func (c *Circle) CollideConvex(p ConvexPolygon) (Contact, bool) {
+	return collideCircleCoords(c, p.GetCoords())
+}
+
+// satPoint represents a point with float coordinates
+type satPoint struct {
+	x, z float64
+}
+
+// satPoints converts coordinates to float points in counter-clockwise order
+func satPoints(coords []Coord) []satPoint {
+	points := make([]satPoint, len(coords))
+	var area int64
+	for i, p := range coords {
+		points[i] = satPoint{x: float64(p.X), z: float64(p.Z)}
+		q := coords[(i+1)%len(coords)]
+		area += int64(p.X)*int64(q.Z) - int64(q.X)*int64(p.Z)
+	}
+	if area < 0 {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+	return points
+}
+
+// edgeNormal returns the outward unit normal of edge i of a counter-clockwise polygon
+func edgeNormal(points []satPoint, i int) satPoint {
+	a := points[i]
+	b := points[(i+1)%len(points)]
+	dx, dz := b.x-a.x, b.z-a.z
+	length := math.Hypot(dx, dz)
+	if length == 0 {
+		return satPoint{}
+	}
+	return satPoint{x: dz / length, z: -dx / length}
+}
+
+// maxSeparation returns the edge of a whose outward normal separates b the most
+// A negative separation is the penetration depth of b through that edge.
+func maxSeparation(a, b []satPoint) (int, float64) {
+	edge := -1
+	best := math.Inf(-1)
+	for i := range a {
+		n := edgeNormal(a, i)
+		if n == (satPoint{}) {
+			continue
+		}
+		sep := math.Inf(1)
+		for _, p := range b {
+			sep = min(sep, n.x*(p.x-a[i].x)+n.z*(p.z-a[i].z))
+		}
+		if sep > best {
+			edge, best = i, sep
+		}
+	}
+	return edge, best
+}
+
+// collideCoords checks if two convex polygons overlap
+func collideCoords(coordsA, coordsB []Coord) (Contact, bool) {
+	a, b := satPoints(coordsA), satPoints(coordsB)
+	if len(a) < 2 || len(b) < 2 {
+		return Contact{}, false
+	}
+	edgeA, sepA := maxSeparation(a, b)
+	if edgeA < 0 || util.AC.Greater(sepA, 0) {
+		return Contact{}, false
+	}
+	edgeB, sepB := maxSeparation(b, a)
+	if edgeB < 0 || util.AC.Greater(sepB, 0) {
+		return Contact{}, false
+	}
+
+	// The reference edge is the one with the smallest penetration, A is preferred on ties
+	ref, inc, edge, flip := a, b, edgeA, false
+	if util.AC.Greater(sepB, sepA) {
+		ref, inc, edge, flip = b, a, edgeB, true
+	}
+	n := edgeNormal(ref, edge)
+	contact := clipContact(ref, inc, edge, n)
+	if flip {
+		n = satPoint{x: -n.x, z: -n.z}
+	}
+	return Contact{
+		Normal: satVector(n),
+		Depth:  max(-max(sepA, sepB), 0),
+		Coord:  satCoord(contact),
+	}, true
+}
+
+// clipContact clips the incident edge against the side planes of the reference edge
+// Returns the average of the clipped points lying behind the reference edge
+// Reference: https://dyn4j.org/2011/11/contact-points-using-clipping/
+func clipContact(ref, inc []satPoint, edge int, n satPoint) satPoint {
+	// The incident edge faces the reference edge the most
+	incEdge := 0
+	best := math.Inf(1)
+	for i := range inc {
+		m := edgeNormal(inc, i)
+		if dot := m.x*n.x + m.z*n.z; dot < best {
+			incEdge, best = i, dot
+		}
+	}
+	r1, r2 := ref[edge], ref[(edge+1)%len(ref)]
+	points := []satPoint{inc[incEdge], inc[(incEdge+1)%len(inc)]}
+
+	// Side planes along the reference edge tangent
+	t := satPoint{x: -n.z, z: n.x}
+	points = clipSegment(points, t, t.x*r1.x+t.z*r1.z)
+	points = clipSegment(points, satPoint{x: -t.x, z: -t.z}, -(t.x*r2.x + t.z*r2.z))
+
+	var sum satPoint
+	count := 0
+	for _, p := range points {
+		if util.AC.SmallerOrEqual(n.x*(p.x-r1.x)+n.z*(p.z-r1.z), 0) {
+			sum.x += p.x
+			sum.z += p.z
+			count++
+		}
+	}
+	if count == 0 {
+		// Vertex contact, the deepest incident point is used
+		deepest := inc[0]
+		for _, p := range inc {
+			if n.x*p.x+n.z*p.z < n.x*deepest.x+n.z*deepest.z {
+				deepest = p
+			}
+		}
+		return deepest
+	}
+	return satPoint{x: sum.x / float64(count), z: sum.z / float64(count)}
+}
+
+// clipSegment keeps the part of the segment where dot(dir, p) >= offset
+func clipSegment(points []satPoint, dir satPoint, offset float64) []satPoint {
+	if len(points) < 2 {
+		return points
+	}
+	d1 := dir.x*points[0].x + dir.z*points[0].z - offset
+	d2 := dir.x*points[1].x + dir.z*points[1].z - offset
+	var ret []satPoint
+	if d1 >= 0 {
+		ret = append(ret, points[0])
+	}
+	if d2 >= 0 {
+		ret = append(ret, points[1])
+	}
+	if d1*d2 < 0 {
+		ratio := d1 / (d1 - d2)
+		ret = append(ret, satPoint{
+			x: points[0].x + ratio*(points[1].x-points[0].x),
+			z: points[0].z + ratio*(points[1].z-points[0].z),
+		})
+	}
+	return ret
+}
+
+// collideCircleCoords checks if a circle overlaps a convex polygon
+func collideCircleCoords(c *Circle, coords []Coord) (Contact, bool) {
+	points := satPoints(coords)
+	if len(points) < 2 {
+		return Contact{}, false
+	}
+	center := satPoint{x: float64(c.Center.X), z: float64(c.Center.Z)}
+	radius := float64(c.Radius)
+
+	// The edge with the largest separation is the exit face when the center is inside
+	edge := -1
+	maxSep := math.Inf(-1)
+	for i := range points {
+		n := edgeNormal(points, i)
+		if n == (satPoint{}) {
+			continue
+		}
+		sep := n.x*(center.x-points[i].x) + n.z*(center.z-points[i].z)
+		if sep > maxSep {
+			edge, maxSep = i, sep
+		}
+	}
+	if edge < 0 {
+		return Contact{}, false
+	}
+
+	if util.AC.SmallerOrEqual(maxSep, 0) {
+		// Center inside the polygon, the circle is pushed out through the nearest edge
+		n := edgeNormal(points, edge)
+		return Contact{
+			Normal: satVector(satPoint{x: -n.x, z: -n.z}),
+			Depth:  radius - maxSep,
+			Coord:  satCoord(satPoint{x: center.x - n.x*maxSep, z: center.z - n.z*maxSep}),
+		}, true
+	}
+
+	// Center outside the polygon, the closest boundary point decides
+	closest := satPoint{}
+	dst := math.Inf(1)
+	for i := range points {
+		p := closestOnSegment(points[i], points[(i+1)%len(points)], center)
+		if d := math.Hypot(p.x-center.x, p.z-center.z); d < dst {
+			closest, dst = p, d
+		}
+	}
+	if util.AC.Greater(dst, radius) {
+		return Contact{}, false
+	}
+	return Contact{
+		Normal: satVector(satPoint{x: (closest.x - center.x) / dst, z: (closest.z - center.z) / dst}),
+		Depth:  radius - dst,
+		Coord:  satCoord(closest),
+	}, true
+}
+
+// closestOnSegment returns the point of segment a, b closest to p
+func closestOnSegment(a, b, p satPoint) satPoint {
+	dx, dz := b.x-a.x, b.z-a.z
+	lengthSquared := dx*dx + dz*dz
+	if lengthSquared == 0 {
+		return a
+	}
+	t := ((p.x-a.x)*dx + (p.z-a.z)*dz) / lengthSquared
+	t = max(0, min(1, t))
+	return satPoint{x: a.x + t*dx, z: a.z + t*dz}
+}
+
+// satVector converts a unit direction to a vector with length 1000
+func satVector(n satPoint) Vector {
+	return Vector{X: int32(math.Round(n.x * 1000)), Z: int32(math.Round(n.z * 1000))}
+}
+
+// satCoord rounds a float point to a coordinate
+func satCoord(p satPoint) Coord {
+	return Coord{X: int32(math.Round(p.x)), Z: int32(math.Round(p.z))}
+}
@@ -0,0 +1,108 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCollideConvex(t *testing.T) {
+	square := NewRectangle(0, 0, 100, 100)
+	tests := []struct {
+		name    string
+		a, b    ConvexPolygon
+		want    Contact
+		wantHit bool
+	}{
+		{
+			name: "face overlap",
+			a:    &square, b: ptr(NewRectangle(80, 20, 100, 60)),
+			want: Contact{Normal: Vector{X: 1000, Z: 0}, Depth: 20, Coord: Coord{X: 80, Z: 50}}, wantHit: true,
+		},
+		{
+			name: "face overlap flipped",
+			a:    ptr(NewRectangle(80, 20, 100, 60)), b: &square,
+			want: Contact{Normal: Vector{X: -1000, Z: 0}, Depth: 20, Coord: Coord{X: 100, Z: 50}}, wantHit: true,
+		},
+		{
+			name: "vertex into a face",
+			a:    &square, b: &Triangle{Vertices: []Vertice{{Coord: Coord{X: 50, Z: 90}}, {Coord: Coord{X: 70, Z: 130}}, {Coord: Coord{X: 30, Z: 130}}}},
+			want: Contact{Normal: Vector{X: 0, Z: 1000}, Depth: 10, Coord: Coord{X: 50, Z: 90}}, wantHit: true,
+		},
+		{
+			name: "touching",
+			a:    &square, b: ptr(NewRectangle(100, 20, 50, 50)),
+			want: Contact{Normal: Vector{X: 1000, Z: 0}, Depth: 0, Coord: Coord{X: 100, Z: 45}}, wantHit: true,
+		},
+		{
+			name: "separated",
+			a:    &square, b: ptr(NewRectangle(101, 20, 50, 50)),
+			wantHit: false,
+		},
+		{
+			name: "separated along a diagonal",
+			a:    &square, b: ptr(NewOBB(Coord{X: 130, Z: 130}, 20, 20, math.Pi/4)),
+			wantHit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := CollideConvex(tt.a, tt.b)
+			if hit != tt.wantHit || got != tt.want {
+				t.Errorf("CollideConvex() = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+			flipped, hit := CollideConvex(tt.b, tt.a)
+			if hit != tt.wantHit || flipped.Normal != (Vector{X: -got.Normal.X, Z: -got.Normal.Z}) || flipped.Depth != got.Depth {
+				t.Errorf("flipped CollideConvex() = %+v, %v, want the opposite normal and depth %v", flipped, hit, got.Depth)
+			}
+		})
+	}
+}
+
+func TestCircleCollideConvex(t *testing.T) {
+	square := NewRectangle(0, 0, 100, 100)
+	tests := []struct {
+		name    string
+		circle  Circle
+		want    Contact
+		wantHit bool
+	}{
+		{
+			name:   "outside a face",
+			circle: NewCirCle(Coord{X: 150, Z: 50}, 60),
+			want:   Contact{Normal: Vector{X: -1000, Z: 0}, Depth: 10, Coord: Coord{X: 100, Z: 50}}, wantHit: true,
+		},
+		{
+			name:   "center inside",
+			circle: NewCirCle(Coord{X: 90, Z: 50}, 5),
+			want:   Contact{Normal: Vector{X: -1000, Z: 0}, Depth: 15, Coord: Coord{X: 100, Z: 50}}, wantHit: true,
+		},
+		{
+			name:   "touching a face",
+			circle: NewCirCle(Coord{X: 50, Z: 105}, 5),
+			want:   Contact{Normal: Vector{X: 0, Z: -1000}, Depth: 0, Coord: Coord{X: 50, Z: 100}}, wantHit: true,
+		},
+		{
+			name:   "corner",
+			circle: NewCirCle(Coord{X: 110, Z: 110}, 15),
+			want:   Contact{Normal: Vector{X: -707, Z: -707}, Depth: 15 - 10*math.Sqrt2, Coord: Coord{X: 100, Z: 100}}, wantHit: true,
+		},
+		{
+			name:    "past the corner",
+			circle:  NewCirCle(Coord{X: 110, Z: 110}, 14),
+			wantHit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := tt.circle.CollideConvex(&square)
+			if hit != tt.wantHit || got.Normal != tt.want.Normal || got.Coord != tt.want.Coord || math.Abs(got.Depth-tt.want.Depth) > 1e-9 {
+				t.Errorf("CollideConvex() = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+		})
+	}
+}
+
+// ptr returns a pointer to a copy of the value
+func ptr[T any](v T) *T {
+	return &v
+}
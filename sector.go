@@ -0,0 +1,213 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/busyster996/geo/util"
+)
+
+// Sector represents a circular sector (fan) with center, radius, facing direction and half-angle
+type Sector struct {
+	Center    Coord   // Center coordinate of the sector
+	Radius    int32   // Radius of the sector
+	Dir       Vector  // Facing direction, does not need to be normalized
+	HalfAngle float64 // Half of the opening angle in radians, in [0, π]
+}
+
+// NewSector creates a new sector with given center, radius, facing direction and half-angle
+func NewSector(center Coord, radius int32, dir Vector, halfAngle float64) Sector {
+	return Sector{
+		Center:    center,
+		Radius:    radius,
+		Dir:       dir,
+		HalfAngle: halfAngle,
+	}
+}
+
+// GetEdgeCoords returns the ends of the arc, rotated by -HalfAngle and +HalfAngle from the facing direction
+func (s *Sector) GetEdgeCoords() (Coord, Coord) {
+	return s.arcCoord(-s.HalfAngle), s.arcCoord(s.HalfAngle)
+}
+
+// arcCoord returns the point at Radius in the facing direction rotated by angle
+func (s *Sector) arcCoord(angle float64) Coord {
+	length := s.Dir.Length()
+	if length == 0 {
+		return s.Center
+	}
+	x, z := float64(s.Dir.X)/length, float64(s.Dir.Z)/length
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	r := float64(s.Radius)
+	return Coord{
+		X: s.Center.X + int32(math.Round((x*cos-z*sin)*r)),
+		Z: s.Center.Z + int32(math.Round((x*sin+z*cos)*r)),
+	}
+}
+
+// inAngle checks if the direction from the center to p is inside the opening angle
+func (s *Sector) inAngle(p Coord) bool {
+	if p == s.Center || s.HalfAngle >= math.Pi {
+		return true
+	}
+	vec := NewVector(s.Center, p)
+	return s.Dir.GetAngle(&vec) <= s.HalfAngle+util.Epsilon
+}
+
+// IsCoordInside checks if point is inside the sector, points on the boundary are inside
+func (s *Sector) IsCoordInside(p Coord) bool {
+	r := float64(s.Radius)
+	if CalDstCoordToCoordWithoutSqrt(s.Center, p) > r*r {
+		return false
+	}
+	return s.inAngle(p)
+}
+
+// IsInterCircle checks if the sector intersects with circle
+func (s *Sector) IsInterCircle(c *Circle) bool {
+	dst := CalDstCoordToCoord(s.Center, c.Center)
+	if util.AC.Greater(dst, float64(s.Radius)+float64(c.Radius)) {
+		return false
+	}
+	// Circle center inside the opening angle: the circle reaches the sector or its arc
+	if s.inAngle(c.Center) {
+		return true
+	}
+	// Otherwise the circle must touch one of the straight edges
+	left, right := s.GetEdgeCoords()
+	r := float64(c.Radius)
+	for _, edge := range [2]Coord{left, right} {
+		seg := NewSegment(s.Center, edge)
+		if util.AC.SmallerOrEqual(seg.CalCoordDst(c.Center), r) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInterSegment checks if the sector intersects with line segment
+func (s *Sector) IsInterSegment(seg *Segment) bool {
+	if s.IsCoordInside(seg.A) || s.IsCoordInside(seg.B) {
+		return true
+	}
+	// Crossing one of the straight edges
+	left, right := s.GetEdgeCoords()
+	for _, edge := range [2]Coord{left, right} {
+		if IsRectCross(s.Center, edge, seg.A, seg.B) && IsLineSegmentCross(s.Center, edge, seg.A, seg.B) {
+			return true
+		}
+	}
+	// Crossing the arc, both endpoints are outside so the segment meets the circle twice
+	ax, az := float64(seg.A.X-s.Center.X), float64(seg.A.Z-s.Center.Z)
+	dx, dz := float64(seg.B.X-seg.A.X), float64(seg.B.Z-seg.A.Z)
+	a := dx*dx + dz*dz
+	b := ax*dx + az*dz
+	c := ax*ax + az*az - float64(s.Radius)*float64(s.Radius)
+	discriminant := b*b - a*c
+	if a == 0 || discriminant < 0 {
+		return false
+	}
+	sqrt := math.Sqrt(discriminant)
+	for _, t := range [2]float64{(-b - sqrt) / a, (-b + sqrt) / a} {
+		if t < 0 || t > 1 {
+			continue
+		}
+		p := Coord{
+			X: seg.A.X + int32(math.Round(t*dx)),
+			Z: seg.A.Z + int32(math.Round(t*dz)),
+		}
+		if s.inAngle(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInterConvex checks if the sector intersects with a convex polygon
+func (s *Sector) IsInterConvex(p ConvexPolygon) bool {
+	coords := p.GetCoords()
+	if NewSimplePolygon(coords, 0).IsCoordInside(s.Center) {
+		return true
+	}
+	for i := range coords {
+		seg := NewSegment(coords[i], coords[(i+1)%len(coords)])
+		if s.IsInterSegment(&seg) {
+			return true
+		}
+	}
+	return false
+}
+
+// Support returns the farthest point of the sector in the direction
+// Sectors wider than a half circle are not convex, the support is the one of their convex hull
+func (s *Sector) Support(dir Vector) Coord {
+	left, right := s.GetEdgeCoords()
+	coords := []Coord{s.Center, left, right}
+	circle := NewCirCle(s.Center, s.Radius)
+	if p := circle.Support(dir); s.inAngle(p) {
+		coords = append(coords, p)
+	}
+	return supportCoords(coords, dir)
+}
+
+// farthestCoord returns the point of the sector farthest from p
+func (s *Sector) farthestCoord(p Coord) Coord {
+	left, right := s.GetEdgeCoords()
+	coords := []Coord{s.Center, left, right}
+	circle := NewCirCle(s.Center, s.Radius)
+	if arc := circle.Support(NewVector(p, s.Center)); s.inAngle(arc) {
+		coords = append(coords, arc)
+	}
+	farthest := coords[0]
+	for _, c := range coords[1:] {
+		if CalDstCoordToCoordWithoutSqrt(p, c) > CalDstCoordToCoordWithoutSqrt(p, farthest) {
+			farthest = c
+		}
+	}
+	return farthest
+}
+
+// halves splits the sector into convex sectors
+// Sectors wider than a half circle are split along the facing direction.
+func (s *Sector) halves() []Sector {
+	length := s.Dir.Length()
+	if s.HalfAngle <= math.Pi/2 || length == 0 {
+		return []Sector{*s}
+	}
+	x, z := float64(s.Dir.X)/length, float64(s.Dir.Z)/length
+	half := s.HalfAngle / 2
+	sectors := make([]Sector, 0, 2)
+	for _, angle := range [2]float64{-half, half} {
+		cos, sin := math.Cos(angle), math.Sin(angle)
+		dir := satVector(satPoint{x: x*cos - z*sin, z: x*sin + z*cos})
+		sectors = append(sectors, NewSector(s.Center, s.Radius, dir, half))
+	}
+	return sectors
+}
+
+// ToRect returns the bounding rectangle of the sector
+// Returns the minimum and maximum X,Z coordinates of the bounding rectangle
+func (s *Sector) ToRect() (minX, minZ, maxX, maxZ int32) {
+	left, right := s.GetEdgeCoords()
+	minX, minZ = min(s.Center.X, left.X, right.X), min(s.Center.Z, left.Z, right.Z)
+	maxX, maxZ = max(s.Center.X, left.X, right.X), max(s.Center.Z, left.Z, right.Z)
+	// Axis extremes of the circle reached by the arc
+	extremes := [4]Coord{
+		{X: s.Center.X + s.Radius, Z: s.Center.Z},
+		{X: s.Center.X - s.Radius, Z: s.Center.Z},
+		{X: s.Center.X, Z: s.Center.Z + s.Radius},
+		{X: s.Center.X, Z: s.Center.Z - s.Radius},
+	}
+	for _, p := range extremes {
+		if s.inAngle(p) {
+			minX, minZ = min(minX, p.X), min(minZ, p.Z)
+			maxX, maxZ = max(maxX, p.X), max(maxZ, p.Z)
+		}
+	}
+	return minX, minZ, maxX, maxZ
+}
+
+// GetLocationToBorder returns the relative position between sector and border
+func (s *Sector) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := s.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
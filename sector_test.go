@@ -0,0 +1,121 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// quarterSector is a sector of radius 100 at the origin facing +X with a 90 degree opening
+var quarterSector = NewSector(Coord{}, 100, Vector{X: 1, Z: 0}, math.Pi/4)
+
+func TestSectorIsCoordInside(t *testing.T) {
+	wide := NewSector(Coord{}, 100, Vector{X: 2, Z: 0}, math.Pi)
+	tests := []struct {
+		name   string
+		sector Sector
+		p      Coord
+		want   bool
+	}{
+		{name: "center", sector: quarterSector, p: Coord{}, want: true},
+		{name: "on the axis", sector: quarterSector, p: Coord{X: 50, Z: 0}, want: true},
+		{name: "on the arc", sector: quarterSector, p: Coord{X: 100, Z: 0}, want: true},
+		{name: "past the arc", sector: quarterSector, p: Coord{X: 101, Z: 0}, want: false},
+		{name: "on an edge", sector: quarterSector, p: Coord{X: 50, Z: -50}, want: true},
+		{name: "past an edge", sector: quarterSector, p: Coord{X: 50, Z: 51}, want: false},
+		{name: "behind", sector: quarterSector, p: Coord{X: -10, Z: 0}, want: false},
+		{name: "full circle behind", sector: wide, p: Coord{X: -50, Z: 0}, want: true},
+		{name: "full circle past the arc", sector: wide, p: Coord{X: -80, Z: 80}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sector.IsCoordInside(tt.p); got != tt.want {
+				t.Errorf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectorIsInter(t *testing.T) {
+	circles := []struct {
+		name   string
+		circle Circle
+		want   bool
+	}{
+		{name: "touching the arc", circle: NewCirCle(Coord{X: 150, Z: 0}, 50), want: true},
+		{name: "short of the arc", circle: NewCirCle(Coord{X: 150, Z: 0}, 49), want: false},
+		{name: "behind the center", circle: NewCirCle(Coord{X: -20, Z: 0}, 10), want: false},
+		{name: "reaching the center", circle: NewCirCle(Coord{X: -20, Z: 0}, 20), want: true},
+		{name: "beside an edge", circle: NewCirCle(Coord{X: 50, Z: 80}, 15), want: false},
+		{name: "across an edge", circle: NewCirCle(Coord{X: 50, Z: 80}, 22), want: true},
+	}
+	for _, tt := range circles {
+		t.Run("circle "+tt.name, func(t *testing.T) {
+			if got := quarterSector.IsInterCircle(&tt.circle); got != tt.want {
+				t.Errorf("IsInterCircle(%v) = %v, want %v", tt.circle, got, tt.want)
+			}
+		})
+	}
+
+	segments := []struct {
+		name    string
+		segment Segment
+		want    bool
+	}{
+		{name: "inside", segment: NewSegment(Coord{X: 50, Z: -10}, Coord{X: 50, Z: 10}), want: true},
+		{name: "across both edges", segment: NewSegment(Coord{X: 30, Z: 60}, Coord{X: 30, Z: -60}), want: true},
+		{name: "across the arc", segment: NewSegment(Coord{X: 95, Z: -50}, Coord{X: 95, Z: 50}), want: true},
+		{name: "past the arc", segment: NewSegment(Coord{X: 110, Z: -50}, Coord{X: 110, Z: 50}), want: false},
+		{name: "behind", segment: NewSegment(Coord{X: -10, Z: -50}, Coord{X: -10, Z: 50}), want: false},
+	}
+	for _, tt := range segments {
+		t.Run("segment "+tt.name, func(t *testing.T) {
+			if got := quarterSector.IsInterSegment(&tt.segment); got != tt.want {
+				t.Errorf("IsInterSegment(%v) = %v, want %v", tt.segment, got, tt.want)
+			}
+		})
+	}
+
+	rects := []struct {
+		name string
+		rect Rectangle
+		want bool
+	}{
+		{name: "around the center", rect: NewRectangle(-10, -10, 20, 20), want: true},
+		{name: "across the arc", rect: NewRectangle(90, -5, 20, 10), want: true},
+		{name: "past the arc", rect: NewRectangle(120, -10, 20, 20), want: false},
+		{name: "behind", rect: NewRectangle(-50, -10, 20, 20), want: false},
+	}
+	for _, tt := range rects {
+		t.Run("convex "+tt.name, func(t *testing.T) {
+			if got := quarterSector.IsInterConvex(&tt.rect); got != tt.want {
+				t.Errorf("IsInterConvex(%v) = %v, want %v", tt.rect, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectorBounds(t *testing.T) {
+	s := quarterSector
+	left, right := s.GetEdgeCoords()
+	if left != (Coord{X: 71, Z: -71}) || right != (Coord{X: 71, Z: 71}) {
+		t.Errorf("GetEdgeCoords() = %v, %v, want {71 -71}, {71 71}", left, right)
+	}
+	if minX, minZ, maxX, maxZ := s.ToRect(); minX != 0 || minZ != -71 || maxX != 100 || maxZ != 71 {
+		t.Errorf("ToRect() = %d, %d, %d, %d, want 0, -71, 100, 71", minX, minZ, maxX, maxZ)
+	}
+	if got := s.Support(Vector{X: 1, Z: 0}); got != (Coord{X: 100, Z: 0}) {
+		t.Errorf("Support() along the facing direction = %v, want {100 0}", got)
+	}
+	if got := s.Support(Vector{X: 0, Z: 1}); got != (Coord{X: 71, Z: 71}) {
+		t.Errorf("Support() across the facing direction = %v, want {71 71}", got)
+	}
+
+	wide := NewSector(Coord{}, 100, Vector{X: 0, Z: 1}, 3*math.Pi/4)
+	if minX, minZ, maxX, maxZ := wide.ToRect(); minX != -100 || minZ != -71 || maxX != 100 || maxZ != 100 {
+		t.Errorf("wide ToRect() = %d, %d, %d, %d, want -100, -71, 100, 100", minX, minZ, maxX, maxZ)
+	}
+	halves := wide.halves()
+	if len(halves) != 2 || halves[0].HalfAngle != 3*math.Pi/8 || halves[0].Dir != (Vector{X: 924, Z: 383}) || halves[1].Dir != (Vector{X: -924, Z: 383}) {
+		t.Errorf("halves() = %+v, want two sectors of half-angle 3π/8", halves)
+	}
+}
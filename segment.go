@@ -1,6 +1,10 @@
 package geo
 
-import "github.com/busyster996/geo/util"
+import (
+	"math"
+
+	"github.com/busyster996/geo/util"
+)
 
 // Segment represents a line segment defined by two endpoints
 type Segment struct {
@@ -21,6 +25,7 @@ func (s *Segment) ToVector() Vector {
 }
 
 // CalCoordDst calculates the distance from a point to the line segment
+// Points beyond either endpoint, and every point of a zero-length segment, are measured to the nearest endpoint.
 func (s *Segment) CalCoordDst(coord Coord) float64 {
 	// Distance from point to segment endpoints
 	a := CalDstCoordToCoord(coord, s.A)
@@ -30,7 +35,7 @@ func (s *Segment) CalCoordDst(coord Coord) float64 {
 	// Check if perpendicular from point intersects with segment
 	ab := NewVector(s.A, s.B)
 	ap := NewVector(s.A, coord)
-	if lab := ab.Length(); util.AC.Greater(ap.Dot(&ab)/lab, lab) {
+	if lab := ab.Length(); lab == 0 || ap.Dot(&ab) < 0 || util.AC.Greater(ap.Dot(&ab)/lab, lab) {
 		return dst
 	}
 
@@ -41,6 +46,85 @@ func (s *Segment) CalCoordDst(coord Coord) float64 {
 	return min(dst, c)
 }
 
+// CalSegmentDst calculates the distance between two line segments, 0 when they cross
+func (s *Segment) CalSegmentDst(o *Segment) float64 {
+	// Proper crossing, touching cases are covered by the endpoint distances
+	d1, d2 := cross(s.B, o.A, s.A), cross(s.B, o.B, s.A)
+	d3, d4 := cross(o.B, s.A, o.A), cross(o.B, s.B, o.A)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return 0
+	}
+	return min(s.CalCoordDst(o.A), s.CalCoordDst(o.B), o.CalCoordDst(s.A), o.CalCoordDst(s.B))
+}
+
+// IsCoordInside checks if point lies on the segment, endpoints included
+func (s *Segment) IsCoordInside(p Coord) bool {
+	return cross(s.B, p, s.A) == 0 && IsRectCross(s.A, s.B, p, p)
+}
+
+// IsInterSegment checks if two line segments intersect, touching segments intersect
+func (s *Segment) IsInterSegment(o *Segment) bool {
+	d1, d2 := cross(s.B, o.A, s.A), cross(s.B, o.B, s.A)
+	d3, d4 := cross(o.B, s.A, o.A), cross(o.B, s.B, o.A)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	// Touching, an endpoint lies on the other segment
+	return s.IsCoordInside(o.A) || s.IsCoordInside(o.B) || o.IsCoordInside(s.A) || o.IsCoordInside(s.B)
+}
+
+// IsInterCircle checks if the segment intersects with circle, segments inside the circle intersect
+func (s *Segment) IsInterCircle(c *Circle) bool {
+	return s.CalCoordDst(c.Center) <= float64(c.Radius)
+}
+
+// IsInterConvex checks if the segment intersects with a convex polygon, segments inside the polygon intersect
+func (s *Segment) IsInterConvex(p ConvexPolygon) bool {
+	coords := p.GetCoords()
+	if NewSimplePolygon(coords, 0).IsCoordInside(s.A) {
+		return true
+	}
+	for i := range coords {
+		edge := NewSegment(coords[i], coords[(i+1)%len(coords)])
+		if s.IsInterSegment(&edge) {
+			return true
+		}
+	}
+	return false
+}
+
+// Support returns the endpoint farthest in the direction
+func (s *Segment) Support(dir Vector) Coord {
+	return supportCoords([]Coord{s.A, s.B}, dir)
+}
+
+// ToRect returns the bounding rectangle of the segment
+// Returns the minimum and maximum X,Z coordinates of the bounding rectangle
+func (s *Segment) ToRect() (minX, minZ, maxX, maxZ int32) {
+	return min(s.A.X, s.B.X), min(s.A.Z, s.B.Z), max(s.A.X, s.B.X), max(s.A.Z, s.B.Z)
+}
+
+// GetLocationToBorder returns the relative position between segment and border
+func (s *Segment) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := s.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// ClosestCoord returns the point on the segment closest to the given point
+func (s *Segment) ClosestCoord(coord Coord) Coord {
+	ab := NewVector(s.A, s.B)
+	ap := NewVector(s.A, coord)
+	lengthSquared := ab.LengthSquared()
+	if lengthSquared == 0 {
+		return s.A
+	}
+	t := min(max(ap.Dot(&ab)/lengthSquared, 0), 1)
+	return Coord{
+		X: s.A.X + int32(math.Round(t*float64(ab.X))),
+		Z: s.A.Z + int32(math.Round(t*float64(ab.Z))),
+	}
+}
+
 // Pan translates the segment parallel by given distance
 // Left-hand coordinate system, moves in direction of positive cross product
 func (s *Segment) Pan(dst int32, positive bool) Segment {
@@ -0,0 +1,50 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSegmentCalCoordDst(t *testing.T) {
+	tests := []struct {
+		name  string
+		seg   Segment
+		coord Coord
+		want  float64
+	}{
+		{name: "perpendicular", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}), coord: Coord{X: 40, Z: 30}, want: 30},
+		{name: "on the segment", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 100}), coord: Coord{X: 50, Z: 50}, want: 0},
+		{name: "behind A", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}), coord: Coord{X: -30, Z: 40}, want: 50},
+		{name: "behind A on the line", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}), coord: Coord{X: -20, Z: 0}, want: 20},
+		{name: "beyond B", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}), coord: Coord{X: 130, Z: -40}, want: 50},
+		{name: "zero length", seg: NewSegment(Coord{X: 10, Z: 10}, Coord{X: 10, Z: 10}), coord: Coord{X: 13, Z: 14}, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.seg.CalCoordDst(tt.coord); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CalCoordDst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentClosestCoord(t *testing.T) {
+	tests := []struct {
+		name  string
+		seg   Segment
+		coord Coord
+		want  Coord
+	}{
+		{name: "perpendicular", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}), coord: Coord{X: 40, Z: 30}, want: Coord{X: 40, Z: 0}},
+		{name: "behind A", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 0}), coord: Coord{X: -30, Z: 40}, want: Coord{X: 0, Z: 0}},
+		{name: "beyond B", seg: NewSegment(Coord{X: 0, Z: 0}, Coord{X: 100, Z: 100}), coord: Coord{X: 200, Z: 150}, want: Coord{X: 100, Z: 100}},
+		{name: "zero length", seg: NewSegment(Coord{X: 10, Z: 10}, Coord{X: 10, Z: 10}), coord: Coord{X: 13, Z: 14}, want: Coord{X: 10, Z: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.seg.ClosestCoord(tt.coord); got != tt.want {
+				t.Errorf("ClosestCoord() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
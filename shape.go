@@ -0,0 +1,293 @@
+package geo
+
+import (
+	"math"
+)
+
+// Shape represents a 2D shape usable with the shape-agnostic Intersects and Collide
+// Circle, Rectangle, Triangle, Convex, OBB, Segment, Capsule, Sector, Annulus and Ellipse implement it.
+type Shape interface {
+	ToRect() (minX, minZ, maxX, maxZ int32)      // Get bounding rectangle
+	IsCoordInside(p Coord) bool                  // Check if point is inside the shape
+	GetLocationToBorder(b *Border) LocationState // Get position relative to border
+	Supporter                                    // Support function of the shape or of its convex hull
+}
+
+// shapeKind identifies the row and column of a shape in the dispatch tables
+type shapeKind uint8
+
+const (
+	shapeSupport shapeKind = iota // Other shapes, handled as convex through their support function
+	shapeConvex                   // Shapes implementing ConvexPolygon
+	shapeCircle
+	shapeSegment
+	shapeCapsule
+	shapeSector
+	shapeAnnulus
+	shapeEllipse
+	shapeKindCount
+)
+
+// intersectFunc and collideFunc are the entries of the dispatch tables
+type (
+	intersectFunc func(a, b Shape) bool
+	collideFunc   func(a, b Shape) (Contact, bool)
+)
+
+// intersectTable holds the exact tests of shape pairs, nil entries fall back to GJK
+var intersectTable [shapeKindCount][shapeKindCount]intersectFunc
+
+// collideTable holds the contact functions of shape pairs, nil entries fall back to GJK and EPA
+var collideTable [shapeKindCount][shapeKindCount]collideFunc
+
+func init() {
+	intersectTable[shapeConvex][shapeConvex] = func(a, b Shape) bool {
+		_, ok := CollideConvex(a.(ConvexPolygon), b.(ConvexPolygon))
+		return ok
+	}
+	intersectTable[shapeCircle][shapeConvex] = func(a, b Shape) bool {
+		_, ok := a.(*Circle).CollideConvex(b.(ConvexPolygon))
+		return ok
+	}
+	intersectTable[shapeCircle][shapeCircle] = func(a, b Shape) bool {
+		return a.(*Circle).IsInterCircle(b.(*Circle))
+	}
+	intersectTable[shapeSegment][shapeConvex] = func(a, b Shape) bool {
+		return a.(*Segment).IsInterConvex(b.(ConvexPolygon))
+	}
+	intersectTable[shapeSegment][shapeCircle] = func(a, b Shape) bool {
+		return a.(*Segment).IsInterCircle(b.(*Circle))
+	}
+	intersectTable[shapeSegment][shapeSegment] = func(a, b Shape) bool {
+		return a.(*Segment).IsInterSegment(b.(*Segment))
+	}
+	intersectTable[shapeCapsule][shapeConvex] = func(a, b Shape) bool {
+		return a.(*Capsule).IsInterConvex(b.(ConvexPolygon))
+	}
+	intersectTable[shapeCapsule][shapeCircle] = func(a, b Shape) bool {
+		return a.(*Capsule).IsInterCircle(b.(*Circle))
+	}
+	intersectTable[shapeCapsule][shapeSegment] = func(a, b Shape) bool {
+		return a.(*Capsule).IsInterSegment(b.(*Segment))
+	}
+	intersectTable[shapeCapsule][shapeCapsule] = func(a, b Shape) bool {
+		return a.(*Capsule).IsInterCapsule(b.(*Capsule))
+	}
+	intersectTable[shapeSector][shapeConvex] = func(a, b Shape) bool {
+		return a.(*Sector).IsInterConvex(b.(ConvexPolygon))
+	}
+	intersectTable[shapeSector][shapeCircle] = func(a, b Shape) bool {
+		return a.(*Sector).IsInterCircle(b.(*Circle))
+	}
+	intersectTable[shapeSector][shapeSegment] = func(a, b Shape) bool {
+		return a.(*Sector).IsInterSegment(b.(*Segment))
+	}
+	intersectTable[shapeEllipse][shapeConvex] = func(a, b Shape) bool {
+		return a.(*Ellipse).IsInterConvex(b.(ConvexPolygon))
+	}
+	intersectTable[shapeEllipse][shapeCircle] = func(a, b Shape) bool {
+		return a.(*Ellipse).IsInterCircle(b.(*Circle))
+	}
+	intersectTable[shapeEllipse][shapeSegment] = func(a, b Shape) bool {
+		return a.(*Ellipse).IsInterSegment(b.(*Segment))
+	}
+
+	collideTable[shapeConvex][shapeConvex] = func(a, b Shape) (Contact, bool) {
+		return CollideConvex(a.(ConvexPolygon), b.(ConvexPolygon))
+	}
+	collideTable[shapeCircle][shapeConvex] = func(a, b Shape) (Contact, bool) {
+		return a.(*Circle).CollideConvex(b.(ConvexPolygon))
+	}
+
+	// The annulus is not convex, it is handled against every kind
+	for kind := range shapeKindCount {
+		intersectTable[shapeAnnulus][kind] = func(a, b Shape) bool {
+			return intersectAnnulus(a.(*Annulus), b)
+		}
+		collideTable[shapeAnnulus][kind] = func(a, b Shape) (Contact, bool) {
+			return collideAnnulus(a.(*Annulus), b)
+		}
+	}
+}
+
+// kindOf returns the dispatch kind of the shape
+func kindOf(s Shape) shapeKind {
+	switch s.(type) {
+	case *Circle:
+		return shapeCircle
+	case *Segment:
+		return shapeSegment
+	case *Capsule:
+		return shapeCapsule
+	case *Sector:
+		return shapeSector
+	case *Annulus:
+		return shapeAnnulus
+	case *Ellipse:
+		return shapeEllipse
+	case ConvexPolygon:
+		return shapeConvex
+	}
+	return shapeSupport
+}
+
+// Intersects checks if two shapes intersect, touching shapes intersect
+// Pairs without an exact test are checked with GJK on their support functions.
+func Intersects(a, b Shape) bool {
+	ka, kb := kindOf(a), kindOf(b)
+	if fn := intersectTable[ka][kb]; fn != nil {
+		return fn(a, b)
+	}
+	if fn := intersectTable[kb][ka]; fn != nil {
+		return fn(b, a)
+	}
+	// Keep a stable order so that rounded supports give the same answer both ways
+	if ka > kb {
+		a, b = b, a
+	}
+	for _, pa := range convexParts(a) {
+		for _, pb := range convexParts(b) {
+			if _, hit := gjk(pa, pb); hit {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Collide checks if two shapes overlap and returns the contact with the normal from a to b
+// Pairs without a dedicated solver are resolved with GJK and EPA, sectors wider than
+// a half circle are resolved against their deepest overlapping half.
+func Collide(a, b Shape) (Contact, bool) {
+	ka, kb := kindOf(a), kindOf(b)
+	if fn := collideTable[ka][kb]; fn != nil {
+		return fn(a, b)
+	}
+	if fn := collideTable[kb][ka]; fn != nil {
+		return flipContact(fn(b, a))
+	}
+	if ka > kb {
+		return flipContact(collideParts(b, a))
+	}
+	return collideParts(a, b)
+}
+
+// collideParts resolves the deepest overlap between the convex parts of two shapes
+func collideParts(a, b Shape) (Contact, bool) {
+	var deepest Contact
+	found := false
+	for _, pa := range convexParts(a) {
+		for _, pb := range convexParts(b) {
+			if contact, ok := GJKPenetration(pa, pb); ok && (!found || contact.Depth > deepest.Depth) {
+				deepest, found = contact, true
+			}
+		}
+	}
+	return deepest, found
+}
+
+// flipContact reverses the contact normal when the shapes were resolved in swapped order
+func flipContact(contact Contact, ok bool) (Contact, bool) {
+	contact.Normal = Vector{X: -contact.Normal.X, Z: -contact.Normal.Z}
+	return contact, ok
+}
+
+// convexParts splits the shape into convex parts for GJK
+func convexParts(s Shape) []Supporter {
+	sector, ok := s.(*Sector)
+	if !ok {
+		return []Supporter{s}
+	}
+	halves := sector.halves()
+	parts := make([]Supporter, len(halves))
+	for i := range halves {
+		parts[i] = &halves[i]
+	}
+	return parts
+}
+
+// intersectAnnulus checks if the annulus intersects with a connected shape
+// The shape must reach the outer circle without lying inside the hole.
+func intersectAnnulus(a *Annulus, s Shape) bool {
+	outer := NewCirCle(a.Center, a.OuterRadius)
+	if !Intersects(&outer, s) {
+		return false
+	}
+	inner := float64(a.InnerRadius)
+	return CalDstCoordToCoordWithoutSqrt(a.Center, farthestCoord(s, a.Center)) >= inner*inner
+}
+
+// collideAnnulus checks if the annulus overlaps a connected shape
+// The shape is pushed out through the nearest boundary, outward past the outer circle
+// or inward into the hole.
+func collideAnnulus(a *Annulus, s Shape) (Contact, bool) {
+	outer := NewCirCle(a.Center, a.OuterRadius)
+	contact, ok := Collide(&outer, s)
+	if !ok {
+		return Contact{}, false
+	}
+	far := farthestCoord(s, a.Center)
+	dst := CalDstCoordToCoord(a.Center, far)
+	inner := float64(a.InnerRadius)
+	if dst < inner {
+		return Contact{}, false
+	}
+	depth := dst - inner
+	if depth >= contact.Depth || dst == 0 {
+		return contact, true
+	}
+	normal := satPoint{x: float64(a.Center.X-far.X) / dst, z: float64(a.Center.Z-far.Z) / dst}
+	// Moving the shape inward is the same as moving the center outward, it must then fit in the hole
+	center := satCoord(satPoint{x: float64(a.Center.X) - normal.x*depth, z: float64(a.Center.Z) - normal.z*depth})
+	if CalDstCoordToCoord(center, farthestCoord(s, center)) > inner+1 {
+		return contact, true
+	}
+	return Contact{Normal: satVector(normal), Depth: depth, Coord: far}, true
+}
+
+// farthestCoord returns the point of the shape farthest from p
+// Other shapes are bounded by the corners of their bounding rectangle.
+func farthestCoord(s Shape, p Coord) Coord {
+	var coords []Coord
+	switch shape := s.(type) {
+	case *Circle:
+		return circleFarthestCoord(shape, p)
+	case *Annulus:
+		outer := NewCirCle(shape.Center, shape.OuterRadius)
+		return circleFarthestCoord(&outer, p)
+	case *Capsule:
+		end := shape.Segment.A
+		if CalDstCoordToCoordWithoutSqrt(p, shape.Segment.B) > CalDstCoordToCoordWithoutSqrt(p, shape.Segment.A) {
+			end = shape.Segment.B
+		}
+		circle := NewCirCle(end, shape.Radius)
+		return circleFarthestCoord(&circle, p)
+	case *Sector:
+		return shape.farthestCoord(p)
+	case *Ellipse:
+		return shape.farthestCoord(p)
+	case *Segment:
+		coords = []Coord{shape.A, shape.B}
+	case ConvexPolygon:
+		coords = shape.GetCoords()
+	default:
+		minX, minZ, maxX, maxZ := s.ToRect()
+		coords = []Coord{{X: minX, Z: minZ}, {X: maxX, Z: minZ}, {X: maxX, Z: maxZ}, {X: minX, Z: maxZ}}
+	}
+	farthest, best := p, math.Inf(-1)
+	for _, c := range coords {
+		if dst := CalDstCoordToCoordWithoutSqrt(p, c); dst > best {
+			farthest, best = c, dst
+		}
+	}
+	return farthest
+}
+
+// circleFarthestCoord returns the point of the circle farthest from p
+func circleFarthestCoord(c *Circle, p Coord) Coord {
+	dir := NewVector(p, c.Center)
+	if dir == (Vector{}) {
+		dir.X = 1
+	}
+	return c.Support(dir)
+}
@@ -0,0 +1,163 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// diamondShape is a shape known only through its support function, resolved with GJK
+type diamondShape struct {
+	center Coord
+	radius int32
+}
+
+func (d *diamondShape) coords() []Coord {
+	c, r := d.center, d.radius
+	return []Coord{{X: c.X + r, Z: c.Z}, {X: c.X, Z: c.Z + r}, {X: c.X - r, Z: c.Z}, {X: c.X, Z: c.Z - r}}
+}
+
+func (d *diamondShape) ToRect() (minX, minZ, maxX, maxZ int32) {
+	return d.center.X - d.radius, d.center.Z - d.radius, d.center.X + d.radius, d.center.Z + d.radius
+}
+
+func (d *diamondShape) IsCoordInside(p Coord) bool {
+	return abs(p.X-d.center.X)+abs(p.Z-d.center.Z) <= d.radius
+}
+
+func (d *diamondShape) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := d.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+func (d *diamondShape) Support(dir Vector) Coord {
+	return supportCoords(d.coords(), dir)
+}
+
+// abs returns the absolute value of v
+func abs(v int32) int32 {
+	return max(v, -v)
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		shape Shape
+		want  shapeKind
+	}{
+		{shape: ptr(NewCirCle(Coord{}, 1)), want: shapeCircle},
+		{shape: ptr(NewSegment(Coord{}, Coord{X: 1})), want: shapeSegment},
+		{shape: ptr(NewCapsule(Coord{}, Coord{X: 1}, 1)), want: shapeCapsule},
+		{shape: ptr(NewSector(Coord{}, 1, Vector{X: 1}, 1)), want: shapeSector},
+		{shape: ptr(NewAnnulus(Coord{}, 1, 2)), want: shapeAnnulus},
+		{shape: ptr(NewEllipse(Coord{}, 2, 1, 0)), want: shapeEllipse},
+		{shape: ptr(NewRectangle(0, 0, 1, 1)), want: shapeConvex},
+		{shape: ptr(NewOBB(Coord{}, 1, 1, 0)), want: shapeConvex},
+		{shape: &Triangle{}, want: shapeConvex},
+		{shape: &Convex{}, want: shapeConvex},
+		{shape: &diamondShape{}, want: shapeSupport},
+	}
+	for _, tt := range tests {
+		if got := kindOf(tt.shape); got != tt.want {
+			t.Errorf("kindOf(%T) = %d, want %d", tt.shape, got, tt.want)
+		}
+	}
+}
+
+func TestIntersectsDispatch(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Shape
+		want bool
+	}{
+		{name: "circles touching", a: ptr(NewCirCle(Coord{}, 10)), b: ptr(NewCirCle(Coord{X: 25}, 15)), want: true},
+		{name: "circles apart", a: ptr(NewCirCle(Coord{}, 10)), b: ptr(NewCirCle(Coord{X: 26}, 15)), want: false},
+		{name: "circle touching a rectangle", a: ptr(NewCirCle(Coord{}, 10)), b: ptr(NewRectangle(10, -5, 10, 10)), want: true},
+		{name: "circle beside a rectangle", a: ptr(NewCirCle(Coord{}, 10)), b: ptr(NewRectangle(11, -5, 10, 10)), want: false},
+		{name: "rectangles overlapping", a: ptr(NewRectangle(0, 0, 10, 10)), b: ptr(NewOBB(Coord{X: 15, Z: 5}, 6, 2, 0)), want: true},
+		{name: "segment into a rectangle", a: ptr(NewSegment(Coord{X: -10, Z: 5}, Coord{X: 5, Z: 5})), b: ptr(NewRectangle(0, 0, 10, 10)), want: true},
+		{name: "segment above a rectangle", a: ptr(NewSegment(Coord{X: -10, Z: 11}, Coord{X: 20, Z: 11})), b: ptr(NewRectangle(0, 0, 10, 10)), want: false},
+		{name: "capsule reaching a segment", a: ptr(NewCapsule(Coord{}, Coord{X: 100}, 20)), b: ptr(NewSegment(Coord{X: 50, Z: 20}, Coord{X: 50, Z: 90})), want: true},
+		{name: "sector facing away from a circle", a: ptr(NewSector(Coord{}, 100, Vector{X: 1}, math.Pi/4)), b: ptr(NewCirCle(Coord{X: -30}, 20)), want: false},
+		{name: "ellipse touching a circle", a: ptr(NewEllipse(Coord{}, 100, 50, 0)), b: ptr(NewCirCle(Coord{Z: 70}, 20)), want: true},
+		{name: "ellipse below a circle", a: ptr(NewEllipse(Coord{}, 100, 50, 0)), b: ptr(NewCirCle(Coord{Z: 71}, 20)), want: false},
+		{name: "circle in the annulus hole", a: ptr(NewAnnulus(Coord{}, 50, 100)), b: ptr(NewCirCle(Coord{}, 40)), want: false},
+		{name: "circle across the annulus hole", a: ptr(NewAnnulus(Coord{}, 50, 100)), b: ptr(NewCirCle(Coord{}, 60)), want: true},
+		{name: "circle touching the annulus", a: ptr(NewAnnulus(Coord{}, 50, 100)), b: ptr(NewCirCle(Coord{Z: 150}, 50)), want: true},
+		{name: "diamond overlapping a rectangle", a: &diamondShape{radius: 10}, b: ptr(NewRectangle(5, -5, 10, 10)), want: true},
+		{name: "diamond beside a rectangle", a: &diamondShape{radius: 10}, b: ptr(NewRectangle(12, -5, 10, 10)), want: false},
+		{name: "diamond behind a wide sector", a: &diamondShape{center: Coord{X: -50}, radius: 10}, b: ptr(NewSector(Coord{}, 100, Vector{X: 1}, 3*math.Pi/4)), want: false},
+		{name: "diamond in a wide sector", a: &diamondShape{center: Coord{X: -50, Z: 60}, radius: 10}, b: ptr(NewSector(Coord{}, 100, Vector{X: 1}, 3*math.Pi/4)), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Intersects(tt.a, tt.b); got != tt.want {
+				t.Errorf("Intersects(a, b) = %v, want %v", got, tt.want)
+			}
+			if got := Intersects(tt.b, tt.a); got != tt.want {
+				t.Errorf("Intersects(b, a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollideDispatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Shape
+		want    Contact // Normal and depth, the depth is checked within epaTolerance
+		angle   float64 // Normal tolerance in radians
+		wantHit bool
+	}{
+		{
+			name: "circle and rectangle",
+			a:    ptr(NewCirCle(Coord{}, 10)), b: ptr(NewRectangle(5, -5, 10, 10)),
+			want: Contact{Normal: Vector{X: 1000}, Depth: 5}, angle: 0.01, wantHit: true,
+		},
+		{
+			name: "rectangles",
+			a:    ptr(NewRectangle(0, 0, 100, 100)), b: ptr(NewRectangle(80, 20, 100, 60)),
+			want: Contact{Normal: Vector{X: 1000}, Depth: 20}, angle: 0.01, wantHit: true,
+		},
+		{
+			name: "circles",
+			a:    ptr(NewCirCle(Coord{}, 100)), b: ptr(NewCirCle(Coord{X: 150}, 100)),
+			want: Contact{Normal: Vector{X: 1000}, Depth: 50}, angle: math.Acos((150 - epaTolerance) / 150), wantHit: true,
+		},
+		{
+			name: "annulus and circle",
+			a:    ptr(NewAnnulus(Coord{}, 500, 1000)), b: ptr(NewCirCle(Coord{Z: 1200}, 300)),
+			want: Contact{Normal: Vector{Z: 1000}, Depth: 100}, angle: math.Acos((1200 - epaTolerance) / 1200), wantHit: true,
+		},
+		{
+			name: "capsule and diamond",
+			a:    ptr(NewCapsule(Coord{}, Coord{X: 1000}, 200)), b: &diamondShape{center: Coord{X: 500, Z: 400}, radius: 300},
+			want: Contact{Normal: Vector{Z: 1000}, Depth: 100}, angle: 0.01, wantHit: true,
+		},
+		{
+			name: "apart",
+			a:    ptr(NewCirCle(Coord{}, 10)), b: ptr(NewRectangle(11, -5, 10, 10)),
+			wantHit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := Collide(tt.a, tt.b)
+			if hit != tt.wantHit || !nearContact(got, tt.want, tt.angle) {
+				t.Errorf("Collide(a, b) = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+			flipped := Contact{Normal: Vector{X: -tt.want.Normal.X, Z: -tt.want.Normal.Z}, Depth: tt.want.Depth}
+			if got, hit := Collide(tt.b, tt.a); hit != tt.wantHit || !nearContact(got, flipped, tt.angle) {
+				t.Errorf("Collide(b, a) = %+v, %v, want %+v, %v", got, hit, flipped, tt.wantHit)
+			}
+		})
+	}
+}
+
+// nearContact checks if the contact has the wanted depth and a normal within angle of the wanted one
+func nearContact(got, want Contact, angle float64) bool {
+	if got == want {
+		return true
+	}
+	g := math.Atan2(float64(got.Normal.Z), float64(got.Normal.X))
+	w := math.Atan2(float64(want.Normal.Z), float64(want.Normal.X))
+	return math.Abs(math.Remainder(g-w, 2*math.Pi)) <= angle && math.Abs(got.Depth-want.Depth) <= epaTolerance
+}
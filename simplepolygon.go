@@ -0,0 +1,343 @@
+package geo
+
+import (
+	"math"
+)
+
+// SimplePolygon represents a simple polygon which may be concave
+type SimplePolygon struct {
+	Index    int32     // Unique identifier for the polygon
+	Vertices []Vertice // Vertices of the polygon in either winding order
+	EdgeIDs  []int32   // Edge identifiers
+}
+
+// NewSimplePolygon creates a simple polygon from coordinates, vertex indices are the coordinate positions
+func NewSimplePolygon(coords []Coord, id int32) *SimplePolygon {
+	vertices := make([]Vertice, len(coords))
+	for i, c := range coords {
+		vertices[i] = Vertice{Index: int32(i), Coord: c}
+	}
+	return &SimplePolygon{
+		Index:    id,
+		Vertices: vertices,
+	}
+}
+
+// IsCoordInside checks if point is inside the polygon with the winding number rule
+// Points on the edges are considered as inside
+func (s *SimplePolygon) IsCoordInside(p Coord) bool {
+	if s.isCoordOnEdge(p) {
+		return true
+	}
+	return s.WindingNumber(p) != 0
+}
+
+// WindingNumber returns how many times the polygon winds around the point
+// Positive for counter-clockwise windings, 0 when the point is outside
+// Reference: https://en.wikipedia.org/wiki/Point_in_polygon#Winding_number_algorithm
+func (s *SimplePolygon) WindingNumber(p Coord) int {
+	wn := 0
+	n := len(s.Vertices)
+	for i := 0; i < n; i++ {
+		a := s.Vertices[i].Coord
+		b := s.Vertices[(i+1)%n].Coord
+		if a.Z <= p.Z {
+			// Upward crossing with p on the left
+			if b.Z > p.Z && cross(b, p, a) > 0 {
+				wn++
+			}
+		} else if b.Z <= p.Z && cross(b, p, a) < 0 {
+			// Downward crossing with p on the right
+			wn--
+		}
+	}
+	return wn
+}
+
+// isCoordOnEdge checks if point lies on any edge of the polygon
+func (s *SimplePolygon) isCoordOnEdge(p Coord) bool {
+	n := len(s.Vertices)
+	for i := 0; i < n; i++ {
+		a := s.Vertices[i].Coord
+		b := s.Vertices[(i+1)%n].Coord
+		if cross(a, b, p) == 0 &&
+			min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+			min(a.Z, b.Z) <= p.Z && p.Z <= max(a.Z, b.Z) {
+			return true
+		}
+	}
+	return false
+}
+
+// SignedArea returns the signed area, positive for counter-clockwise polygons
+func (s *SimplePolygon) SignedArea() float64 {
+	var area int64
+	n := len(s.Vertices)
+	for i := 0; i < n; i++ {
+		a := s.Vertices[i].Coord
+		b := s.Vertices[(i+1)%n].Coord
+		area += int64(a.X)*int64(b.Z) - int64(b.X)*int64(a.Z)
+	}
+	return float64(area) / 2
+}
+
+// Area returns the area of the polygon
+func (s *SimplePolygon) Area() float64 {
+	return math.Abs(s.SignedArea())
+}
+
+// Orientation returns 1 for counter-clockwise, -1 for clockwise, 0 for degenerate polygons
+func (s *SimplePolygon) Orientation() int32 {
+	area := s.SignedArea()
+	if area > 0 {
+		return 1
+	} else if area < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Perimeter returns the total length of the polygon edges
+func (s *SimplePolygon) Perimeter() float64 {
+	var length float64
+	n := len(s.Vertices)
+	for i := 0; i < n; i++ {
+		length += CalDstCoordToCoord(s.Vertices[i].Coord, s.Vertices[(i+1)%n].Coord)
+	}
+	return length
+}
+
+// GetVectors returns vector array of the polygon in counter-clockwise order
+func (s *SimplePolygon) GetVectors() []Vector {
+	vecs := make([]Vector, 0, len(s.Vertices))
+	for _, v := range s.Vertices {
+		vecs = append(vecs, NewVectorByCoord(v.Coord))
+	}
+	if s.Orientation() < 0 {
+		for i, j := 0, len(vecs)-1; i < j; i, j = i+1, j-1 {
+			vecs[i], vecs[j] = vecs[j], vecs[i]
+		}
+	}
+	return vecs
+}
+
+// ToRect returns the bounding rectangle of the polygon
+// Returns minimum and maximum X,Z coordinates
+func (s *SimplePolygon) ToRect() (minX, minZ, maxX, maxZ int32) {
+	minX = int32(math.MaxInt32)
+	minZ = int32(math.MaxInt32)
+	maxX = int32(math.MinInt32)
+	maxZ = int32(math.MinInt32)
+	for _, v := range s.Vertices {
+		minX = min(v.Coord.X, minX)
+		minZ = min(v.Coord.Z, minZ)
+		maxX = max(v.Coord.X, maxX)
+		maxZ = max(v.Coord.Z, maxZ)
+	}
+	return minX, minZ, maxX, maxZ
+}
+
+// GetLocationToBorder returns the positional relationship between polygon and given border
+func (s *SimplePolygon) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := s.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// GetIndex returns the polygon index
+func (s *SimplePolygon) GetIndex() int32 {
+	return s.Index
+}
+
+// GetEdgeIDs returns the list of edge indices
+func (s *SimplePolygon) GetEdgeIDs() []int32 {
+	return s.EdgeIDs
+}
+
+// GetEdgeMidCoords returns the midpoints of all edges
+func (s *SimplePolygon) GetEdgeMidCoords() []Coord {
+	n := len(s.Vertices)
+	coords := make([]Coord, 0, n)
+	for i, v := range s.Vertices {
+		coords = append(coords, CalMidCoord(v.Coord, s.Vertices[(i+1)%n].Coord))
+	}
+	return coords
+}
+
+// GetVertices returns the list of vertices
+func (s *SimplePolygon) GetVertices() []Vertice {
+	return s.Vertices
+}
+
+// GetCoords returns the vertex coordinates
+func (s *SimplePolygon) GetCoords() []Coord {
+	return verticeCoords(s.Vertices)
+}
+
+// Triangulate splits the polygon into clockwise triangles by ear clipping
+// Triangle vertices keep the vertex indices of the polygon
+func (s *SimplePolygon) Triangulate() ([]*Triangle, error) {
+	triangles, err := EarClipping(s.GetCoords(), nil)
+	if err != nil {
+		return nil, err
+	}
+	remapVertices(triangles, s.Vertices)
+	return triangles, nil
+}
+
+// PolygonWithHoles represents a simple polygon with holes
+type PolygonWithHoles struct {
+	Index int32           // Unique identifier for the polygon
+	Outer SimplePolygon   // Outer boundary
+	Holes []SimplePolygon // Holes inside the outer boundary
+}
+
+// NewPolygonWithHoles creates a polygon with holes from coordinate rings
+// Vertex indices are numbered continuously from the outer ring to the holes
+func NewPolygonWithHoles(outer []Coord, holes [][]Coord, id int32) *PolygonWithHoles {
+	p := &PolygonWithHoles{
+		Index: id,
+		Outer: *NewSimplePolygon(outer, 0),
+	}
+	offset := int32(len(outer))
+	for i, hole := range holes {
+		h := NewSimplePolygon(hole, int32(i+1))
+		for j := range h.Vertices {
+			h.Vertices[j].Index += offset
+		}
+		offset += int32(len(hole))
+		p.Holes = append(p.Holes, *h)
+	}
+	return p
+}
+
+// IsCoordInside checks if point is inside the outer boundary and outside every hole
+// Points on the edges of the boundary and the holes are considered as inside
+func (p *PolygonWithHoles) IsCoordInside(c Coord) bool {
+	if !p.Outer.IsCoordInside(c) {
+		return false
+	}
+	for i := range p.Holes {
+		if !p.Holes[i].isCoordOnEdge(c) && p.Holes[i].WindingNumber(c) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SignedArea returns the area of the outer boundary minus the holes, signed by the outer winding
+func (p *PolygonWithHoles) SignedArea() float64 {
+	area := p.Area()
+	if p.Outer.Orientation() < 0 {
+		return -area
+	}
+	return area
+}
+
+// Area returns the area of the outer boundary minus the holes
+func (p *PolygonWithHoles) Area() float64 {
+	area := p.Outer.Area()
+	for i := range p.Holes {
+		area -= p.Holes[i].Area()
+	}
+	return area
+}
+
+// Orientation returns the orientation of the outer boundary
+func (p *PolygonWithHoles) Orientation() int32 {
+	return p.Outer.Orientation()
+}
+
+// Perimeter returns the total length of the boundary and hole edges
+func (p *PolygonWithHoles) Perimeter() float64 {
+	length := p.Outer.Perimeter()
+	for i := range p.Holes {
+		length += p.Holes[i].Perimeter()
+	}
+	return length
+}
+
+// GetVectors returns vector array of the outer boundary in counter-clockwise order
+func (p *PolygonWithHoles) GetVectors() []Vector {
+	return p.Outer.GetVectors()
+}
+
+// ToRect returns the bounding rectangle of the outer boundary
+func (p *PolygonWithHoles) ToRect() (minX, minZ, maxX, maxZ int32) {
+	return p.Outer.ToRect()
+}
+
+// GetLocationToBorder returns the positional relationship between polygon and given border
+func (p *PolygonWithHoles) GetLocationToBorder(b *Border) LocationState {
+	return p.Outer.GetLocationToBorder(b)
+}
+
+// GetIndex returns the polygon index
+func (p *PolygonWithHoles) GetIndex() int32 {
+	return p.Index
+}
+
+// GetEdgeIDs returns the edge indices of the boundary and the holes
+func (p *PolygonWithHoles) GetEdgeIDs() []int32 {
+	ids := append([]int32(nil), p.Outer.EdgeIDs...)
+	for i := range p.Holes {
+		ids = append(ids, p.Holes[i].EdgeIDs...)
+	}
+	return ids
+}
+
+// GetEdgeMidCoords returns the edge midpoints of the boundary and the holes
+func (p *PolygonWithHoles) GetEdgeMidCoords() []Coord {
+	coords := p.Outer.GetEdgeMidCoords()
+	for i := range p.Holes {
+		coords = append(coords, p.Holes[i].GetEdgeMidCoords()...)
+	}
+	return coords
+}
+
+// GetVertices returns the vertices of the boundary followed by the holes
+func (p *PolygonWithHoles) GetVertices() []Vertice {
+	vertices := append([]Vertice(nil), p.Outer.Vertices...)
+	for i := range p.Holes {
+		vertices = append(vertices, p.Holes[i].Vertices...)
+	}
+	return vertices
+}
+
+// Triangulate splits the polygon into clockwise triangles by ear clipping
+// Triangle vertices keep the vertex indices of the polygon
+func (p *PolygonWithHoles) Triangulate() ([]*Triangle, error) {
+	holes := make([][]Coord, 0, len(p.Holes))
+	for i := range p.Holes {
+		holes = append(holes, p.Holes[i].GetCoords())
+	}
+	triangles, err := EarClipping(p.Outer.GetCoords(), holes)
+	if err != nil {
+		return nil, err
+	}
+	remapVertices(triangles, p.GetVertices())
+	return triangles, nil
+}
+
+// remapVertices replaces triangle vertex indices with the indices of vertices at the same coordinate
+func remapVertices(triangles []*Triangle, vertices []Vertice) {
+	lookup := make(map[Coord]int32, len(vertices))
+	for _, v := range vertices {
+		if _, exist := lookup[v.Coord]; !exist {
+			lookup[v.Coord] = v.Index
+		}
+	}
+	for _, t := range triangles {
+		for i := range t.Vertices {
+			t.Vertices[i].Index = lookup[t.Vertices[i].Coord]
+		}
+	}
+}
+
+// verticeCoords returns the coordinates of vertices
+func verticeCoords(vertices []Vertice) []Coord {
+	coords := make([]Coord, len(vertices))
+	for i, v := range vertices {
+		coords[i] = v.Coord
+	}
+	return coords
+}
@@ -0,0 +1,159 @@
+package geo
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// lShape is a counter-clockwise concave polygon with its notch at the top right
+var lShape = []Coord{{X: 0, Z: 0}, {X: 100, Z: 0}, {X: 100, Z: 40}, {X: 40, Z: 40}, {X: 40, Z: 100}, {X: 0, Z: 100}}
+
+// reversedCoords returns a reversed copy of the coordinates
+func reversedCoords(coords []Coord) []Coord {
+	ret := slices.Clone(coords)
+	slices.Reverse(ret)
+	return ret
+}
+
+func TestSimplePolygonIsCoordInside(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Coord
+		want bool
+	}{
+		{name: "inside the foot", p: Coord{X: 80, Z: 20}, want: true},
+		{name: "inside the leg", p: Coord{X: 20, Z: 80}, want: true},
+		{name: "in the notch", p: Coord{X: 70, Z: 70}, want: false},
+		{name: "on the reflex vertex", p: Coord{X: 40, Z: 40}, want: true},
+		{name: "on the notch edge", p: Coord{X: 70, Z: 40}, want: true},
+		{name: "on the top edge", p: Coord{X: 0, Z: 100}, want: true},
+		{name: "level with a vertex", p: Coord{X: -10, Z: 40}, want: false},
+		{name: "outside", p: Coord{X: 101, Z: 20}, want: false},
+	}
+	ccw := NewSimplePolygon(lShape, 0)
+	cw := NewSimplePolygon(reversedCoords(lShape), 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ccw.IsCoordInside(tt.p); got != tt.want {
+				t.Errorf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+			if got := cw.IsCoordInside(tt.p); got != tt.want {
+				t.Errorf("clockwise IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplePolygonWindingNumber(t *testing.T) {
+	// A star drawn in one stroke winds twice around its center
+	star := NewSimplePolygon([]Coord{{X: 0, Z: 100}, {X: 59, Z: -81}, {X: -95, Z: 31}, {X: 95, Z: 31}, {X: -59, Z: -81}}, 0)
+	tests := []struct {
+		name    string
+		polygon *SimplePolygon
+		p       Coord
+		want    int
+	}{
+		{name: "counter-clockwise", polygon: NewSimplePolygon(lShape, 0), p: Coord{X: 20, Z: 20}, want: 1},
+		{name: "clockwise", polygon: NewSimplePolygon(reversedCoords(lShape), 0), p: Coord{X: 20, Z: 20}, want: -1},
+		{name: "notch", polygon: NewSimplePolygon(lShape, 0), p: Coord{X: 70, Z: 70}, want: 0},
+		{name: "star center", polygon: star, p: Coord{X: 0, Z: 0}, want: -2},
+		{name: "star point", polygon: star, p: Coord{X: 0, Z: 60}, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.polygon.WindingNumber(tt.p); got != tt.want {
+				t.Errorf("WindingNumber(%v) = %d, want %d", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplePolygonMeasures(t *testing.T) {
+	tests := []struct {
+		name        string
+		coords      []Coord
+		area        float64
+		orientation int32
+		perimeter   float64
+	}{
+		{name: "counter-clockwise", coords: lShape, area: 6400, orientation: 1, perimeter: 400},
+		{name: "clockwise", coords: reversedCoords(lShape), area: -6400, orientation: -1, perimeter: 400},
+		{name: "right triangle", coords: []Coord{{X: 0, Z: 0}, {X: 0, Z: 40}, {X: 30, Z: 0}}, area: -600, orientation: -1, perimeter: 120},
+		{name: "collinear", coords: []Coord{{X: 0, Z: 0}, {X: 10, Z: 10}, {X: 20, Z: 20}}, area: 0, orientation: 0, perimeter: 40 * math.Sqrt2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSimplePolygon(tt.coords, 0)
+			if got := s.SignedArea(); got != tt.area {
+				t.Errorf("SignedArea() = %v, want %v", got, tt.area)
+			}
+			if got := s.Area(); got != math.Abs(tt.area) {
+				t.Errorf("Area() = %v, want %v", got, math.Abs(tt.area))
+			}
+			if got := s.Orientation(); got != tt.orientation {
+				t.Errorf("Orientation() = %d, want %d", got, tt.orientation)
+			}
+			if got := s.Perimeter(); math.Abs(got-tt.perimeter) > 1e-9 {
+				t.Errorf("Perimeter() = %v, want %v", got, tt.perimeter)
+			}
+			var coords []Coord
+			for _, v := range s.GetVectors() {
+				coords = append(coords, Coord{X: v.X, Z: v.Z})
+			}
+			if got := NewSimplePolygon(coords, 0).Orientation(); got != max(tt.orientation, -tt.orientation) {
+				t.Errorf("GetVectors() orientation = %d, want counter-clockwise", got)
+			}
+		})
+	}
+}
+
+func TestPolygonWithHoles(t *testing.T) {
+	p := NewPolygonWithHoles(squareRing(0, 0, 100, true), [][]Coord{squareRing(20, 20, 20, false), squareRing(60, 60, 30, true)}, 7)
+
+	if got := p.Area(); got != 100*100-20*20-30*30 {
+		t.Errorf("Area() = %v, want %v", got, 100*100-20*20-30*30)
+	}
+	if got := p.SignedArea(); got != -(100*100 - 20*20 - 30*30) {
+		t.Errorf("SignedArea() = %v, want %v", got, -(100*100 - 20*20 - 30*30))
+	}
+	if got := p.Perimeter(); got != 400+80+120 {
+		t.Errorf("Perimeter() = %v, want %v", got, 400+80+120)
+	}
+	if got := len(p.GetVertices()); got != 12 || p.GetVertices()[11].Index != 11 {
+		t.Errorf("GetVertices() = %v, want 12 vertices indexed 0 to 11", p.GetVertices())
+	}
+
+	tests := []struct {
+		name string
+		p    Coord
+		want bool
+	}{
+		{name: "between the holes", p: Coord{X: 50, Z: 10}, want: true},
+		{name: "in the first hole", p: Coord{X: 30, Z: 30}, want: false},
+		{name: "in the second hole", p: Coord{X: 75, Z: 75}, want: false},
+		{name: "on a hole edge", p: Coord{X: 20, Z: 30}, want: true},
+		{name: "on the outer edge", p: Coord{X: 100, Z: 50}, want: true},
+		{name: "outside", p: Coord{X: 101, Z: 50}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.IsCoordInside(tt.p); got != tt.want {
+				t.Errorf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+
+	triangles, err := p.Triangulate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTriangulation(t, triangles, 2*(100*100-20*20-30*30), NewRectangle(20, 20, 20, 20), NewRectangle(60, 60, 30, 30))
+	for _, tri := range triangles {
+		for _, v := range tri.Vertices {
+			if want := p.GetVertices()[v.Index].Coord; v.Coord != want {
+				t.Errorf("Triangulate() vertex %d at %v, want %v", v.Index, v.Coord, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package geo
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	slideMaxIterations = 4   // Maximum number of walls hit in one move
+	slideSkin          = 1.0 // Distance kept between the circle and the walls it hits
+)
+
+// MoveAndSlide moves the circle along delta and slides it along the walls it hits
+// Every hit stops the circle at the time of impact, the remaining motion is projected on the wall tangent
+// and swept again. When two walls form a corner the circle stops in it.
+// Walls out of reach are skipped with Segment.CalCoordDst. The position and the remaining motion stay
+// in floating point between hits, rounding them to Coord and Vector after every slide would drift by up
+// to a unit per wall and push the circle into the skin, so their dot products are the float form of Vector.Dot.
+// Returns the final center and the positions in walls of the walls touched, in hit order
+func MoveAndSlide(circle Circle, delta Vector, walls []Segment) (Coord, []int) {
+	pos := coordPoint(circle.Center)
+	remaining := vectorPoint(delta)
+	radius := float64(circle.Radius)
+
+	// Walls farther than the whole motion, with a unit for rounding, are never hit
+	reach := radius + math.Sqrt(delta.Dot(&delta)) + slideSkin*slideMaxIterations + 1
+	var near []int
+	for i := range walls {
+		if walls[i].CalCoordDst(circle.Center) <= reach {
+			near = append(near, i)
+		}
+	}
+
+	var touched []int
+	var prevNormal satPoint
+	for range slideMaxIterations {
+		if math.Hypot(remaining.x, remaining.z) < slideSkin {
+			break
+		}
+
+		wall := -1
+		var best sweepResult
+		for _, i := range near {
+			hit, ok := sweepSegment(pos, radius, remaining, coordPoint(walls[i].A), coordPoint(walls[i].B))
+			if !ok {
+				continue
+			}
+			// Walls already touched do not block a motion going along or away from them
+			if hit.time == 0 && hit.normal.x*remaining.x+hit.normal.z*remaining.z >= 0 {
+				continue
+			}
+			if wall < 0 || hit.time < best.time {
+				wall, best = i, hit
+			}
+		}
+		if wall < 0 {
+			pos.x += remaining.x
+			pos.z += remaining.z
+			remaining = satPoint{}
+			break
+		}
+
+		n := best.normal
+		pos.x += remaining.x*best.time + n.x*slideSkin
+		pos.z += remaining.z*best.time + n.z*slideSkin
+		if !slices.Contains(touched, wall) {
+			touched = append(touched, wall)
+		}
+
+		// The remaining motion keeps only its component along the wall
+		rest := 1 - best.time
+		remaining = satPoint{x: remaining.x * rest, z: remaining.z * rest}
+		dot := remaining.x*n.x + remaining.z*n.z
+		remaining = satPoint{x: remaining.x - n.x*dot, z: remaining.z - n.z*dot}
+		if remaining.x*prevNormal.x+remaining.z*prevNormal.z < 0 {
+			break
+		}
+		prevNormal = n
+	}
+	return satCoord(pos), touched
+}
@@ -0,0 +1,68 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMoveAndSlide(t *testing.T) {
+	vertical := NewSegment(Coord{X: 100, Z: -1000}, Coord{X: 100, Z: 1000})
+	horizontal := NewSegment(Coord{X: -1000, Z: 100}, Coord{X: 1000, Z: 100})
+	far := NewSegment(Coord{X: 5000, Z: -1000}, Coord{X: 5000, Z: 1000})
+	tests := []struct {
+		name    string
+		delta   Vector
+		walls   []Segment
+		want    Coord
+		touched []int
+	}{
+		{
+			name:  "no walls",
+			delta: Vector{X: 100, Z: 50},
+			want:  Coord{X: 100, Z: 50},
+		},
+		{
+			name:  "short of the wall",
+			delta: Vector{X: 50, Z: 0},
+			walls: []Segment{vertical},
+			want:  Coord{X: 50, Z: 0},
+		},
+		{
+			// Stops at the wall minus the radius and the skin
+			name:    "head on",
+			delta:   Vector{X: 200, Z: 0},
+			walls:   []Segment{far, vertical},
+			want:    Coord{X: 89, Z: 0},
+			touched: []int{1},
+		},
+		{
+			// Hits the wall at z=45, the remaining 110 x 55 keeps its 55 along the wall
+			name:    "slide",
+			delta:   Vector{X: 200, Z: 100},
+			walls:   []Segment{vertical},
+			want:    Coord{X: 89, Z: 100},
+			touched: []int{0},
+		},
+		{
+			name:    "corner",
+			delta:   Vector{X: 200, Z: 200},
+			walls:   []Segment{vertical, horizontal},
+			want:    Coord{X: 89, Z: 89},
+			touched: []int{0, 1},
+		},
+		{
+			name:  "along a wall",
+			delta: Vector{X: 0, Z: 300},
+			walls: []Segment{NewSegment(Coord{X: 10, Z: -1000}, Coord{X: 10, Z: 1000})},
+			want:  Coord{X: 0, Z: 300},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, touched := MoveAndSlide(NewCirCle(Coord{X: 0, Z: 0}, 10), tt.delta, tt.walls)
+			if got != tt.want || !slices.Equal(touched, tt.touched) {
+				t.Errorf("MoveAndSlide() = %v, %v, want %v, %v", got, touched, tt.want, tt.touched)
+			}
+		})
+	}
+}
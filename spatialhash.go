@@ -0,0 +1,214 @@
+package geo
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// SpatialHash represents a uniform grid bucketing circles by the cells their bounding rectangle covers
+// Cells are keyed by the Coord of their bottom-left corner, like GetCrossRect.
+// It is a broadphase for many moving circles, pairs it reports still need an exact check.
+type SpatialHash[K comparable] struct {
+	CellWidth  int32 // Cell width
+	CellHeight int32 // Cell height
+
+	cells   map[Coord][]K  // Keys of the circles covering each cell
+	circles map[K]Circle   // Circle of each key
+	ranges  map[K]cellSpan // Cells covered by each circle
+}
+
+// cellSpan represents an inclusive range of cell positions
+type cellSpan struct {
+	minX, minZ, maxX, maxZ int32
+}
+
+// NewSpatialHash creates a spatial hash with the given cell size
+func NewSpatialHash[K comparable](cellWidth, cellHeight int32) *SpatialHash[K] {
+	return &SpatialHash[K]{
+		CellWidth:  max(cellWidth, 1),
+		CellHeight: max(cellHeight, 1),
+		cells:      make(map[Coord][]K),
+		circles:    make(map[K]Circle),
+		ranges:     make(map[K]cellSpan),
+	}
+}
+
+// Len returns the number of circles in the grid
+func (h *SpatialHash[K]) Len() int {
+	return len(h.circles)
+}
+
+// Get returns the circle stored with the key
+func (h *SpatialHash[K]) Get(key K) (Circle, bool) {
+	c, exist := h.circles[key]
+	return c, exist
+}
+
+// Insert adds a circle, returns false if the key already exists
+func (h *SpatialHash[K]) Insert(key K, c Circle) bool {
+	if _, exist := h.circles[key]; exist {
+		return false
+	}
+	span := h.spanOf(&c)
+	h.circles[key] = c
+	h.ranges[key] = span
+	h.add(key, span)
+	return true
+}
+
+// Update moves a circle to its new position and radius
+// Keys not in the grid are inserted, cells are only touched when the covered range changes
+func (h *SpatialHash[K]) Update(key K, c Circle) {
+	old, exist := h.ranges[key]
+	if !exist {
+		h.Insert(key, c)
+		return
+	}
+	h.circles[key] = c
+	span := h.spanOf(&c)
+	if span == old {
+		return
+	}
+	h.del(key, old)
+	h.ranges[key] = span
+	h.add(key, span)
+}
+
+// Remove deletes a circle, returns false if the key does not exist
+func (h *SpatialHash[K]) Remove(key K) bool {
+	span, exist := h.ranges[key]
+	if !exist {
+		return false
+	}
+	h.del(key, span)
+	delete(h.circles, key)
+	delete(h.ranges, key)
+	return true
+}
+
+// QueryRadius returns the keys of circles intersecting the circle of radius around the center
+func (h *SpatialHash[K]) QueryRadius(center Coord, radius int32) []K {
+	query := NewCirCle(center, radius)
+	span := h.spanOf(&query)
+	var ret []K
+	h.visit(span, func(cell Coord, key K) {
+		// Keys covering several cells are reported from the first shared cell only
+		if h.firstCell(span, h.ranges[key]) != cell {
+			return
+		}
+		c := h.circles[key]
+		if c.IsInterCircle(&query) {
+			ret = append(ret, key)
+		}
+	})
+	return ret
+}
+
+// Neighbors returns the keys of other circles intersecting the circle of the key
+func (h *SpatialHash[K]) Neighbors(key K) []K {
+	c, exist := h.circles[key]
+	if !exist {
+		return nil
+	}
+	var ret []K
+	for _, k := range h.QueryRadius(c.Center, c.Radius) {
+		if k != key {
+			ret = append(ret, k)
+		}
+	}
+	return ret
+}
+
+// Pairs returns every pair of circles sharing a cell with overlapping bounding rectangles
+// Each pair is reported once, use Circle.IsInterCircle to keep the colliding ones.
+// Cells are visited from the lowest Z then X, so the same sequence of updates gives the same order.
+func (h *SpatialHash[K]) Pairs() [][2]K {
+	cells := slices.SortedFunc(maps.Keys(h.cells), func(a, b Coord) int {
+		return cmp.Or(cmp.Compare(a.Z, b.Z), cmp.Compare(a.X, b.X))
+	})
+	var ret [][2]K
+	for _, cell := range cells {
+		keys := h.cells[cell]
+		for i := 0; i < len(keys); i++ {
+			a := h.circles[keys[i]]
+			for j := i + 1; j < len(keys); j++ {
+				b := h.circles[keys[j]]
+				if !circleAABB(a).overlaps(circleAABB(b)) {
+					continue
+				}
+				// Pairs sharing several cells are reported from the first shared cell only
+				if h.firstCell(h.ranges[keys[i]], h.ranges[keys[j]]) != cell {
+					continue
+				}
+				ret = append(ret, [2]K{keys[i], keys[j]})
+			}
+		}
+	}
+	return ret
+}
+
+// spanOf returns the cells covered by the bounding rectangle of a circle
+func (h *SpatialHash[K]) spanOf(c *Circle) cellSpan {
+	minX, minZ, maxX, maxZ := c.ToRect()
+	return cellSpan{
+		minX: floorDiv(minX, h.CellWidth),
+		minZ: floorDiv(minZ, h.CellHeight),
+		maxX: floorDiv(maxX, h.CellWidth),
+		maxZ: floorDiv(maxZ, h.CellHeight),
+	}
+}
+
+// cellCoord returns the key of the cell at the position
+func (h *SpatialHash[K]) cellCoord(x, z int32) Coord {
+	return Coord{X: x * h.CellWidth, Z: z * h.CellHeight}
+}
+
+// firstCell returns the key of the bottom-left cell shared by two ranges
+func (h *SpatialHash[K]) firstCell(a, b cellSpan) Coord {
+	return h.cellCoord(max(a.minX, b.minX), max(a.minZ, b.minZ))
+}
+
+// add puts the key into every cell of the range
+func (h *SpatialHash[K]) add(key K, span cellSpan) {
+	for x := span.minX; x <= span.maxX; x++ {
+		for z := span.minZ; z <= span.maxZ; z++ {
+			cell := h.cellCoord(x, z)
+			h.cells[cell] = append(h.cells[cell], key)
+		}
+	}
+}
+
+// del removes the key from every cell of the range, empty cells are dropped
+func (h *SpatialHash[K]) del(key K, span cellSpan) {
+	for x := span.minX; x <= span.maxX; x++ {
+		for z := span.minZ; z <= span.maxZ; z++ {
+			cell := h.cellCoord(x, z)
+			keys := h.cells[cell]
+			for i, k := range keys {
+				if k == key {
+					keys[i] = keys[len(keys)-1]
+					keys = keys[:len(keys)-1]
+					break
+				}
+			}
+			if len(keys) == 0 {
+				delete(h.cells, cell)
+			} else {
+				h.cells[cell] = keys
+			}
+		}
+	}
+}
+
+// visit calls fn for every key in the cells of the range
+func (h *SpatialHash[K]) visit(span cellSpan, fn func(cell Coord, key K)) {
+	for x := span.minX; x <= span.maxX; x++ {
+		for z := span.minZ; z <= span.maxZ; z++ {
+			cell := h.cellCoord(x, z)
+			for _, key := range h.cells[cell] {
+				fn(cell, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+// newSpatialHashCircles returns a spatial hash with 10x10 cells holding named circles
+func newSpatialHashCircles(t *testing.T) *SpatialHash[string] {
+	t.Helper()
+	h := NewSpatialHash[string](10, 10)
+	circles := []struct {
+		key string
+		c   Circle
+	}{
+		{key: "a", c: NewCirCle(Coord{X: 5, Z: 5}, 3)},
+		{key: "b", c: NewCirCle(Coord{X: 9, Z: 5}, 3)},    // Covers two cells, overlaps a
+		{key: "c", c: NewCirCle(Coord{X: 50, Z: 50}, 5)},  // Covers four cells
+		{key: "d", c: NewCirCle(Coord{X: -15, Z: -5}, 4)}, // Negative cell
+		{key: "e", c: NewCirCle(Coord{X: 58, Z: 52}, 5)},  // Shares two cells with c
+		{key: "f", c: NewCirCle(Coord{X: 15, Z: 5}, 1)},   // Shares a cell with b without overlapping
+	}
+	for _, tt := range circles {
+		if !h.Insert(tt.key, tt.c) {
+			t.Fatalf("Insert(%q) = false, want true", tt.key)
+		}
+	}
+	return h
+}
+
+func TestSpatialHashInsert(t *testing.T) {
+	h := newSpatialHashCircles(t)
+	if h.Insert("a", NewCirCle(Coord{}, 1)) || h.Len() != 6 {
+		t.Errorf("Insert() of an existing key = true or Len() = %d, want false and 6", h.Len())
+	}
+	if c, ok := h.Get("a"); !ok || c != NewCirCle(Coord{X: 5, Z: 5}, 3) {
+		t.Errorf("Get(%q) = %v, %v, want the inserted circle", "a", c, ok)
+	}
+	cells := map[Coord][]string{
+		{X: 0, Z: 0}:     {"a", "b"},
+		{X: 10, Z: 0}:    {"b", "f"},
+		{X: -20, Z: -10}: {"d"},
+		{X: 50, Z: 50}:   {"c", "e"},
+		{X: 60, Z: 40}:   {"e"},
+		{X: 40, Z: 40}:   {"c"},
+	}
+	for cell, want := range cells {
+		if got := h.cells[cell]; !slices.Equal(got, want) {
+			t.Errorf("cell %v = %v, want %v", cell, got, want)
+		}
+	}
+	if len(h.cells) != 9 {
+		t.Errorf("grid has %d cells, want 9", len(h.cells))
+	}
+}
+
+func TestSpatialHashQueryRadius(t *testing.T) {
+	h := newSpatialHashCircles(t)
+	tests := []struct {
+		name   string
+		center Coord
+		radius int32
+		want   []string
+	}{
+		{name: "two cells", center: Coord{X: 0, Z: 0}, radius: 8, want: []string{"a", "b"}},
+		{name: "box overlap without contact", center: Coord{X: 0, Z: 0}, radius: 2, want: nil},
+		{name: "negative cell", center: Coord{X: -10, Z: -10}, radius: 4, want: []string{"d"}},
+		{name: "touching", center: Coord{X: 50, Z: 60}, radius: 5, want: []string{"c"}},
+		{name: "empty cells", center: Coord{X: 500, Z: 500}, radius: 50, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.QueryRadius(tt.center, tt.radius)
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("QueryRadius(%v, %d) = %v, want %v", tt.center, tt.radius, got, tt.want)
+			}
+		})
+	}
+	if got := h.Neighbors("c"); !slices.Equal(got, []string{"e"}) {
+		t.Errorf("Neighbors(%q) = %v, want [e]", "c", got)
+	}
+	if got := h.Neighbors("z"); got != nil {
+		t.Errorf("Neighbors() of a missing key = %v, want nil", got)
+	}
+}
+
+func TestSpatialHashPairs(t *testing.T) {
+	h := newSpatialHashCircles(t)
+	if got, want := h.Pairs(), [][2]string{{"a", "b"}, {"c", "e"}}; !slices.Equal(got, want) {
+		t.Errorf("Pairs() = %v, want %v", got, want)
+	}
+
+	h.Update("e", NewCirCle(Coord{X: 200, Z: 200}, 5))
+	h.Update("g", NewCirCle(Coord{X: -14, Z: -4}, 1))
+	if got, want := h.Pairs(), [][2]string{{"d", "g"}, {"a", "b"}}; !slices.Equal(got, want) {
+		t.Errorf("Pairs() after Update() = %v, want %v", got, want)
+	}
+	if _, exist := h.cells[Coord{X: 60, Z: 40}]; exist {
+		t.Error("Update() left the old cells of the circle")
+	}
+
+	if !h.Remove("d") || h.Remove("d") {
+		t.Error("Remove() = false for an existing key or true for a missing key")
+	}
+	if got := h.QueryRadius(Coord{X: -15, Z: -5}, 1); !slices.Equal(got, []string{"g"}) {
+		t.Errorf("QueryRadius() after Remove() = %v, want [g]", got)
+	}
+	if h.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", h.Len())
+	}
+}
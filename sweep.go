@@ -0,0 +1,178 @@
+package geo
+
+import (
+	"math"
+)
+
+// SweepHit represents the first contact of a moving circle
+type SweepHit struct {
+	Time   float64 // Fraction of the displacement at impact, in [0, 1]
+	Center Coord   // Center of the moving circle at impact
+	Coord  Coord   // Contact point
+	Normal Vector  // Surface normal at the contact pointing towards the moving circle, unit vector with length 1000
+}
+
+// SweepSegment finds when the circle moving along delta first touches the segment
+// Circles already touching the segment hit at time 0
+func (c *Circle) SweepSegment(delta Vector, s *Segment) (SweepHit, bool) {
+	center, d, radius := coordPoint(c.Center), vectorPoint(delta), float64(c.Radius)
+	hit, ok := sweepSegment(center, radius, d, coordPoint(s.A), coordPoint(s.B))
+	if !ok {
+		return SweepHit{}, false
+	}
+	return hit.toSweepHit(center, d), true
+}
+
+// SweepConvex finds when the circle moving along delta first touches the convex polygon
+// Circles already overlapping the polygon hit at time 0 with the SAT contact
+func (c *Circle) SweepConvex(delta Vector, p ConvexPolygon) (SweepHit, bool) {
+	coords := p.GetCoords()
+	center, d, radius := coordPoint(c.Center), vectorPoint(delta), float64(c.Radius)
+	if contact, ok := collideCircleCoords(c, coords); ok {
+		return SweepHit{
+			Center: c.Center,
+			Coord:  contact.Coord,
+			Normal: Vector{X: -contact.Normal.X, Z: -contact.Normal.Z},
+		}, true
+	}
+
+	// Starting outside, the first contact with the polygon is the first contact with one of its edges
+	var best sweepResult
+	found := false
+	for i := range coords {
+		a, b := coordPoint(coords[i]), coordPoint(coords[(i+1)%len(coords)])
+		if hit, ok := sweepSegment(center, radius, d, a, b); ok && (!found || hit.time < best.time) {
+			best, found = hit, true
+		}
+	}
+	if !found {
+		return SweepHit{}, false
+	}
+	return best.toSweepHit(center, d), true
+}
+
+// SweepCircle finds when the circle moving along delta first touches the other circle moving along otherDelta
+// The normal points from the other circle to this one
+func (c *Circle) SweepCircle(delta Vector, o *Circle, otherDelta Vector) (SweepHit, bool) {
+	center, d := coordPoint(c.Center), vectorPoint(delta)
+	other, od := coordPoint(o.Center), vectorPoint(otherDelta)
+	// Relative motion against the other circle standing still
+	rel := satPoint{x: d.x - od.x, z: d.z - od.z}
+	t, ok := sweepPoint(center, float64(c.Radius)+float64(o.Radius), rel, other)
+	if !ok {
+		return SweepHit{}, false
+	}
+	at := satPoint{x: center.x + t*d.x, z: center.z + t*d.z}
+	otherAt := satPoint{x: other.x + t*od.x, z: other.z + t*od.z}
+	n := sweepNormal(otherAt, at, rel)
+	radius := float64(o.Radius)
+	return SweepHit{
+		Time:   t,
+		Center: satCoord(at),
+		Coord:  satCoord(satPoint{x: otherAt.x + n.x*radius, z: otherAt.z + n.z*radius}),
+		Normal: satVector(n),
+	}, true
+}
+
+// sweepResult represents a time of impact with the contact point and unit normal
+type sweepResult struct {
+	time          float64
+	point, normal satPoint
+}
+
+// toSweepHit converts the result of a circle starting at center and moving along d
+func (r sweepResult) toSweepHit(center, d satPoint) SweepHit {
+	return SweepHit{
+		Time:   r.time,
+		Center: satCoord(satPoint{x: center.x + r.time*d.x, z: center.z + r.time*d.z}),
+		Coord:  satCoord(r.point),
+		Normal: satVector(r.normal),
+	}
+}
+
+// sweepSegment finds the time of impact of a circle moving along d against segment a, b
+func sweepSegment(center satPoint, radius float64, d, a, b satPoint) (sweepResult, bool) {
+	// Already touching
+	closest := closestOnSegment(a, b, center)
+	if dst := math.Hypot(center.x-closest.x, center.z-closest.z); dst <= radius {
+		return sweepResult{point: closest, normal: sweepNormal(closest, center, d)}, true
+	}
+
+	best := sweepResult{time: math.Inf(1)}
+	// The face of the segment, the normal is taken on the side of the center
+	ex, ez := b.x-a.x, b.z-a.z
+	if length := math.Hypot(ex, ez); length > 0 {
+		n := satPoint{x: ez / length, z: -ex / length}
+		dst := n.x*(center.x-a.x) + n.z*(center.z-a.z)
+		if dst < 0 {
+			n, dst = satPoint{x: -n.x, z: -n.z}, -dst
+		}
+		if speed := n.x*d.x + n.z*d.z; speed < 0 {
+			t := (dst - radius) / -speed
+			at := satPoint{x: center.x + t*d.x, z: center.z + t*d.z}
+			u := ((at.x-a.x)*ex + (at.z-a.z)*ez) / (length * length)
+			if t >= 0 && t <= 1 && u >= 0 && u <= 1 {
+				best = sweepResult{
+					time:   t,
+					point:  satPoint{x: at.x - n.x*radius, z: at.z - n.z*radius},
+					normal: n,
+				}
+			}
+		}
+	}
+	// The endpoints of the segment
+	for _, p := range [2]satPoint{a, b} {
+		if t, ok := sweepPoint(center, radius, d, p); ok && t < best.time {
+			at := satPoint{x: center.x + t*d.x, z: center.z + t*d.z}
+			best = sweepResult{time: t, point: p, normal: sweepNormal(p, at, d)}
+		}
+	}
+	return best, !math.IsInf(best.time, 1)
+}
+
+// sweepPoint finds the first time in [0, 1] when a circle moving along d touches point p
+func sweepPoint(center satPoint, radius float64, d, p satPoint) (float64, bool) {
+	mx, mz := center.x-p.x, center.z-p.z
+	c := mx*mx + mz*mz - radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+	a := d.x*d.x + d.z*d.z
+	b := mx*d.x + mz*d.z
+	// Moving away or standing still
+	if a == 0 || b >= 0 {
+		return 0, false
+	}
+	discriminant := b*b - a*c
+	if discriminant < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(discriminant)) / a
+	if t > 1 {
+		return 0, false
+	}
+	return max(t, 0), true
+}
+
+// sweepNormal returns the unit normal from the surface point to the center
+// The opposite of the motion is used when they coincide
+func sweepNormal(surface, center, d satPoint) satPoint {
+	nx, nz := center.x-surface.x, center.z-surface.z
+	if length := math.Hypot(nx, nz); length > 0 {
+		return satPoint{x: nx / length, z: nz / length}
+	}
+	if length := math.Hypot(d.x, d.z); length > 0 {
+		return satPoint{x: -d.x / length, z: -d.z / length}
+	}
+	return satPoint{}
+}
+
+// coordPoint converts a coordinate to a float point
+func coordPoint(p Coord) satPoint {
+	return satPoint{x: float64(p.X), z: float64(p.Z)}
+}
+
+// vectorPoint converts a vector to a float point
+func vectorPoint(v Vector) satPoint {
+	return satPoint{x: float64(v.X), z: float64(v.Z)}
+}
@@ -0,0 +1,144 @@
+package geo
+
+import (
+	"testing"
+)
+
+func TestCircleSweepSegment(t *testing.T) {
+	tests := []struct {
+		name    string
+		circle  Circle
+		delta   Vector
+		segment Segment
+		want    SweepHit
+		wantHit bool
+	}{
+		{
+			name:   "face",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: 100, Z: 0},
+			segment: NewSegment(Coord{X: 50, Z: -50}, Coord{X: 50, Z: 50}),
+			want:    SweepHit{Time: 0.4, Center: Coord{X: 40, Z: 0}, Coord: Coord{X: 50, Z: 0}, Normal: Vector{X: -1000, Z: 0}}, wantHit: true,
+		},
+		{
+			name:   "endpoint",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 25), delta: Vector{X: 100, Z: 0},
+			segment: NewSegment(Coord{X: 50, Z: 20}, Coord{X: 50, Z: 100}),
+			want:    SweepHit{Time: 0.35, Center: Coord{X: 35, Z: 0}, Coord: Coord{X: 50, Z: 20}, Normal: Vector{X: -600, Z: -800}}, wantHit: true,
+		},
+		{
+			name:   "already touching",
+			circle: NewCirCle(Coord{X: 45, Z: 0}, 5), delta: Vector{X: 100, Z: 0},
+			segment: NewSegment(Coord{X: 50, Z: -50}, Coord{X: 50, Z: 50}),
+			want:    SweepHit{Time: 0, Center: Coord{X: 45, Z: 0}, Coord: Coord{X: 50, Z: 0}, Normal: Vector{X: -1000, Z: 0}}, wantHit: true,
+		},
+		{
+			name:   "short of the face",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: 30, Z: 0},
+			segment: NewSegment(Coord{X: 50, Z: -50}, Coord{X: 50, Z: 50}),
+			wantHit: false,
+		},
+		{
+			name:   "moving away",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: -100, Z: 0},
+			segment: NewSegment(Coord{X: 50, Z: -50}, Coord{X: 50, Z: 50}),
+			wantHit: false,
+		},
+		{
+			name:   "passing an endpoint",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: 100, Z: 0},
+			segment: NewSegment(Coord{X: 50, Z: 20}, Coord{X: 50, Z: 100}),
+			wantHit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := tt.circle.SweepSegment(tt.delta, &tt.segment)
+			if hit != tt.wantHit || got != tt.want {
+				t.Errorf("SweepSegment() = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestCircleSweepConvex(t *testing.T) {
+	square := NewRectangle(0, 0, 100, 100)
+	tests := []struct {
+		name    string
+		circle  Circle
+		delta   Vector
+		want    SweepHit
+		wantHit bool
+	}{
+		{
+			name:   "left face",
+			circle: NewCirCle(Coord{X: -50, Z: 50}, 10), delta: Vector{X: 100, Z: 0},
+			want: SweepHit{Time: 0.4, Center: Coord{X: -10, Z: 50}, Coord: Coord{X: 0, Z: 50}, Normal: Vector{X: -1000, Z: 0}}, wantHit: true,
+		},
+		{
+			name:   "touching a corner",
+			circle: NewCirCle(Coord{X: 130, Z: 140}, 50), delta: Vector{X: 0, Z: -100},
+			want: SweepHit{Time: 0, Center: Coord{X: 130, Z: 140}, Coord: Coord{X: 100, Z: 100}, Normal: Vector{X: 600, Z: 800}}, wantHit: true,
+		},
+		{
+			name:   "overlapping",
+			circle: NewCirCle(Coord{X: 90, Z: 50}, 5), delta: Vector{X: -100, Z: 0},
+			want: SweepHit{Time: 0, Center: Coord{X: 90, Z: 50}, Coord: Coord{X: 100, Z: 50}, Normal: Vector{X: 1000, Z: 0}}, wantHit: true,
+		},
+		{
+			name:   "passing by",
+			circle: NewCirCle(Coord{X: -50, Z: 50}, 10), delta: Vector{X: 0, Z: 100},
+			wantHit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := tt.circle.SweepConvex(tt.delta, &square)
+			if hit != tt.wantHit || got != tt.want {
+				t.Errorf("SweepConvex() = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestCircleSweepCircle(t *testing.T) {
+	tests := []struct {
+		name              string
+		circle, other     Circle
+		delta, otherDelta Vector
+		want              SweepHit
+		wantHit           bool
+	}{
+		{
+			name:   "head on",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: 100, Z: 0},
+			other: NewCirCle(Coord{X: 100, Z: 0}, 10), otherDelta: Vector{X: -100, Z: 0},
+			want: SweepHit{Time: 0.4, Center: Coord{X: 40, Z: 0}, Coord: Coord{X: 50, Z: 0}, Normal: Vector{X: -1000, Z: 0}}, wantHit: true,
+		},
+		{
+			name:   "standing target",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: 0, Z: 100},
+			other: NewCirCle(Coord{X: 0, Z: 80}, 20), otherDelta: Vector{},
+			want: SweepHit{Time: 0.5, Center: Coord{X: 0, Z: 50}, Coord: Coord{X: 0, Z: 60}, Normal: Vector{X: 0, Z: -1000}}, wantHit: true,
+		},
+		{
+			name:   "same motion",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: 100, Z: 0},
+			other: NewCirCle(Coord{X: 50, Z: 0}, 10), otherDelta: Vector{X: 100, Z: 0},
+			wantHit: false,
+		},
+		{
+			name:   "overlapping",
+			circle: NewCirCle(Coord{X: 0, Z: 0}, 10), delta: Vector{X: -100, Z: 0},
+			other: NewCirCle(Coord{X: 15, Z: 0}, 10), otherDelta: Vector{},
+			want: SweepHit{Time: 0, Center: Coord{X: 0, Z: 0}, Coord: Coord{X: 5, Z: 0}, Normal: Vector{X: -1000, Z: 0}}, wantHit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := tt.circle.SweepCircle(tt.delta, &tt.other, tt.otherDelta)
+			if hit != tt.wantHit || got != tt.want {
+				t.Errorf("SweepCircle() = %+v, %v, want %+v, %v", got, hit, tt.want, tt.wantHit)
+			}
+		})
+	}
+}
@@ -6,31 +6,37 @@ import (
 
 // Triangle represents a geometric triangle structure
 type Triangle struct {
-	Index         int32     // Unique identifier for the triangle
-	Vertices      []Vertice // Three vertices that form the triangle
-	EdgeIDs       []int32   // Unique IDs of the three edges, generated by server
+	Index    int32     // Unique identifier for the triangle
+	AreaType uint8     // Area type used by QueryFilter for traversal costs
+	Flags    uint16    // Ability flags used by QueryFilter to include or exclude the triangle
+	Vertices []Vertice // Three vertices that form the triangle
+	// IDs of the three edges, generated by server
+	//
+	// Deprecated: IDs collide once a vertex index reaches 10000, use EdgeKeys.
+	EdgeIDs       []int32
 	EdgeKeyString []string  // String keys of the three edges, generated by server
+	EdgeKeys      []EdgeKey // Collision-free 64-bit keys of the three edges
 }
 
 // IsCoordInside determines whether a point is inside the triangle.
-// The triangle vertices are arranged clockwise. When calculating cross products
-// between point p and triangle vectors, if all results are <= 0, the point
-// is on the same side, indicating p is inside the triangle.
-// Note: Collinear cases are also considered as being on the same side.
+// When calculating cross products between point p and triangle vectors, the point
+// is inside the triangle when no two results have opposite signs, so the test
+// holds for clockwise and counter-clockwise vertices alike.
+// Note: Zero results are compatible with either sign, points on the edges and
+// vertices are inside.
 func (t *Triangle) IsCoordInside(p Coord) bool {
 	pa := NewVector(p, t.Vertices[0].Coord)
 	pb := NewVector(p, t.Vertices[1].Coord)
 	pc := NewVector(p, t.Vertices[2].Coord)
 
-	b1 := pa.Cross(&pb) >= 0
-	b2 := pb.Cross(&pc) >= 0
+	c1 := pa.Cross(&pb)
+	c2 := pb.Cross(&pc)
+	c3 := pc.Cross(&pa)
 
-	if b1 != b2 {
-		return false
-	}
-
-	b3 := pc.Cross(&pa) >= 0
-	return b2 == b3
+	// Collinear results are compatible with either side
+	hasNeg := c1 < 0 || c2 < 0 || c3 < 0
+	hasPos := c1 > 0 || c2 > 0 || c3 > 0
+	return !(hasNeg && hasPos)
 }
 
 // GetIndex returns the triangle's unique identifier
@@ -48,6 +54,8 @@ func (t *Triangle) GetLocationToBorder(b *Border) LocationState {
 func (t *Triangle) ToRect() (minX, minZ, maxX, maxZ int32) {
 	minX = int32(math.MaxInt32)
 	minZ = int32(math.MaxInt32)
+	maxX = int32(math.MinInt32)
+	maxZ = int32(math.MinInt32)
 	for _, v := range t.Vertices {
 		minX = min(v.Coord.X, minX)
 		minZ = min(v.Coord.Z, minZ)
@@ -62,6 +70,20 @@ func (t *Triangle) GetEdgeIDs() []int32 {
 	return t.EdgeIDs
 }
 
+// GetEdgeKeys returns the list of 64-bit edge keys for the triangle
+func (t *Triangle) GetEdgeKeys() []EdgeKey {
+	return t.EdgeKeys
+}
+
+// GenEdgeKeys generates the 64-bit keys of the triangle edges from its vertices
+func (t *Triangle) GenEdgeKeys() []EdgeKey {
+	keys := make([]EdgeKey, 0, len(t.Vertices))
+	for i := range t.Vertices {
+		keys = append(keys, GenEdgeKey64(t.Vertices[i].Index, t.Vertices[(i+1)%len(t.Vertices)].Index))
+	}
+	return keys
+}
+
 // GetEdgeMidCoords returns the midpoint coordinates of triangle edges
 func (t *Triangle) GetEdgeMidCoords() []Coord {
 	coords := make([]Coord, 3)
@@ -85,6 +107,24 @@ func (t *Triangle) GetVectors() []Vector {
 	return vecs
 }
 
+// GetCoords returns triangle vertex coordinates arranged counter-clockwise
+func (t *Triangle) GetCoords() []Coord {
+	vecs := t.GetVectors()
+	coords := make([]Coord, len(vecs))
+	for i, v := range vecs {
+		coords[i] = v.ToCoord(Coord{})
+	}
+	return coords
+}
+
+// convexPolygon marks the triangle as a ConvexPolygon
+func (t *Triangle) convexPolygon() {}
+
+// Support returns the triangle vertex farthest in the direction
+func (t *Triangle) Support(dir Vector) Coord {
+	return supportCoords(verticeCoords(t.Vertices), dir)
+}
+
 // GetNeighborEdgeNums counts the number of adjacent edges between two triangles
 func (t *Triangle) GetNeighborEdgeNums(t2 *Triangle) int {
 	var cnt int
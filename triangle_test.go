@@ -0,0 +1,34 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTriangleIsCoordInside(t *testing.T) {
+	clockwise := []Vertice{{Coord: Coord{X: 0, Z: 0}}, {Coord: Coord{X: 0, Z: 100}}, {Coord: Coord{X: 100, Z: 0}}}
+	counterClockwise := slices.Clone(clockwise)
+	slices.Reverse(counterClockwise)
+	tests := []struct {
+		name string
+		p    Coord
+		want bool
+	}{
+		{name: "inside", p: Coord{X: 20, Z: 30}, want: true},
+		{name: "on an edge", p: Coord{X: 50, Z: 0}, want: true},
+		{name: "on the hypotenuse", p: Coord{X: 50, Z: 50}, want: true},
+		{name: "on a vertex", p: Coord{X: 0, Z: 100}, want: true},
+		{name: "outside", p: Coord{X: 51, Z: 50}, want: false},
+		{name: "on the edge line beyond a vertex", p: Coord{X: 150, Z: 0}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, vertices := range [][]Vertice{clockwise, counterClockwise} {
+				tri := &Triangle{Vertices: vertices}
+				if got := tri.IsCoordInside(tt.p); got != tt.want {
+					t.Errorf("IsCoordInside(%v) with %v = %v, want %v", tt.p, tri.GetCoords(), got, tt.want)
+				}
+			}
+		})
+	}
+}